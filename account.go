@@ -19,6 +19,11 @@
 package flow
 
 import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
 	"github.com/pkg/errors"
 
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -32,6 +37,124 @@ type Account struct {
 	Keys    []*AccountKey
 }
 
+// ActiveKeysByWeight returns the keys of this account that can be used to sign transactions,
+// sorted by weight in descending order, with keys of equal weight sorted by ID in ascending order.
+//
+// Account keys cannot currently be revoked, so all keys of the account are returned.
+func (a Account) ActiveKeysByWeight() []*AccountKey {
+	keys := make([]*AccountKey, len(a.Keys))
+	copy(keys, a.Keys)
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Weight != keys[j].Weight {
+			return keys[i].Weight > keys[j].Weight
+		}
+
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
+
+// An AccountDiff describes the changes between two states of an account.
+//
+// Keys are compared by ID. Key lists are sorted by ID in ascending order.
+type AccountDiff struct {
+	BalanceBefore uint64
+	BalanceAfter  uint64
+	// AddedKeys are the keys present only in the later state.
+	AddedKeys []*AccountKey
+	// RemovedKeys are the keys present only in the earlier state.
+	RemovedKeys []*AccountKey
+	// ModifiedKeys are the later states of keys that differ between the two states.
+	//
+	// Sequence numbers are not compared, as they change with every transaction the key proposes.
+	ModifiedKeys []*AccountKey
+	// CodeChanged is true if the account code differs between the two states.
+	CodeChanged bool
+}
+
+// IsEmpty returns true if the diff contains no changes.
+func (d AccountDiff) IsEmpty() bool {
+	return d.BalanceBefore == d.BalanceAfter &&
+		len(d.AddedKeys) == 0 &&
+		len(d.RemovedKeys) == 0 &&
+		len(d.ModifiedKeys) == 0 &&
+		!d.CodeChanged
+}
+
+// DiffAccounts returns the changes from account state a to account state b.
+//
+// A nil account is treated as an account with no balance, keys or code.
+func DiffAccounts(a, b *Account) AccountDiff {
+	if a == nil {
+		a = &Account{}
+	}
+
+	if b == nil {
+		b = &Account{}
+	}
+
+	diff := AccountDiff{
+		BalanceBefore: a.Balance,
+		BalanceAfter:  b.Balance,
+		AddedKeys:     make([]*AccountKey, 0),
+		RemovedKeys:   make([]*AccountKey, 0),
+		ModifiedKeys:  make([]*AccountKey, 0),
+		CodeChanged:   !bytes.Equal(a.Code, b.Code),
+	}
+
+	keysBefore := make(map[int]*AccountKey, len(a.Keys))
+	for _, key := range a.Keys {
+		keysBefore[key.ID] = key
+	}
+
+	keysAfter := make(map[int]*AccountKey, len(b.Keys))
+	for _, key := range b.Keys {
+		keysAfter[key.ID] = key
+	}
+
+	for id, key := range keysAfter {
+		before, ok := keysBefore[id]
+		if !ok {
+			diff.AddedKeys = append(diff.AddedKeys, key)
+			continue
+		}
+
+		if !accountKeysEqual(before, key) {
+			diff.ModifiedKeys = append(diff.ModifiedKeys, key)
+		}
+	}
+
+	for id, key := range keysBefore {
+		if _, ok := keysAfter[id]; !ok {
+			diff.RemovedKeys = append(diff.RemovedKeys, key)
+		}
+	}
+
+	sortAccountKeysByID(diff.AddedKeys)
+	sortAccountKeysByID(diff.RemovedKeys)
+	sortAccountKeysByID(diff.ModifiedKeys)
+
+	return diff
+}
+
+func accountKeysEqual(a, b *AccountKey) bool {
+	if a.SigAlgo != b.SigAlgo ||
+		a.HashAlgo != b.HashAlgo ||
+		a.Weight != b.Weight {
+		return false
+	}
+
+	return a.PublicKey.Equal(b.PublicKey)
+}
+
+func sortAccountKeysByID(keys []*AccountKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+}
+
 // AccountKeyWeightThreshold is the total key weight required to authorize access to an account.
 const AccountKeyWeightThreshold int = 1000
 
@@ -82,6 +205,10 @@ func (a *AccountKey) SetWeight(weight int) *AccountKey {
 }
 
 // Encode returns the canonical RLP byte representation of this account key.
+//
+// The key is encoded as an RLP list of the encoded public key, signature algorithm,
+// hash algorithm and weight, which is the form accepted by the Cadence AuthAccount
+// and addPublicKey APIs. The ID and sequence number are not encoded.
 func (a AccountKey) Encode() []byte {
 	temp := accountKeyWrapper{
 		EncodedPublicKey: a.PublicKey.Encode(),
@@ -108,7 +235,130 @@ func (a AccountKey) Validate() error {
 	return nil
 }
 
-// DecodeAccountKey decodes the RLP byte representation of an account key.
+// Hasher returns a hasher for the hash algorithm of this account key.
+//
+// Signatures produced by this key must be verified using this hasher.
+// An error is returned if the hash algorithm is unknown or unset.
+func (a AccountKey) Hasher() (crypto.Hasher, error) {
+	if a.HashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, errors.New("hash algorithm is not set")
+	}
+
+	return crypto.NewHasher(a.HashAlgo)
+}
+
+// MatchesPrivateKey returns true if the given private key corresponds to the public key
+// and signature algorithm of this account key.
+func (a AccountKey) MatchesPrivateKey(privKey crypto.PrivateKey) bool {
+	return privKey.MatchesPublicKey(a.PublicKey) && a.SigAlgo == privKey.Algorithm()
+}
+
+var (
+	// ErrKeyAlgorithmMismatch indicates that the signature algorithm of an account key
+	// does not match the algorithm of its public key.
+	ErrKeyAlgorithmMismatch = errors.New("flow: signature algorithm does not match public key")
+	// ErrInvalidSignature indicates that a signature was checked and is not valid for a key.
+	ErrInvalidSignature = errors.New("flow: signature is not valid for key")
+)
+
+// A KeyVerificationResult is the result of verifying a signature against a single account key.
+type KeyVerificationResult struct {
+	// KeyID is the ID of the account key.
+	KeyID int
+	// Verified is true if the signature is valid for the key.
+	Verified bool
+	// Err explains why the signature was not verified, and is nil if it was.
+	Err error
+}
+
+// VerifyAgainstAllKeys verifies a signature against each of the given account keys and
+// returns one result per key, in the same order as keys.
+//
+// A key whose algorithms are inconsistent is reported with ErrKeyAlgorithmMismatch or the
+// validation error of the key, and a signature that does not match a key with ErrInvalidSignature.
+// Account keys cannot currently be revoked, so no key is reported as revoked.
+func VerifyAgainstAllKeys(keys []*AccountKey, message, signature []byte) []KeyVerificationResult {
+	results := make([]KeyVerificationResult, len(keys))
+
+	for i, key := range keys {
+		results[i] = KeyVerificationResult{
+			KeyID: key.ID,
+			Err:   verifyWithKey(key, message, signature),
+		}
+		results[i].Verified = results[i].Err == nil
+	}
+
+	return results
+}
+
+func verifyWithKey(key *AccountKey, message, signature []byte) error {
+	if key.PublicKey.Equal(crypto.PublicKey{}) {
+		return errors.New("public key is not set")
+	}
+
+	if key.PublicKey.Algorithm() != key.SigAlgo {
+		return fmt.Errorf(
+			"%w: key uses %s, public key is %s",
+			ErrKeyAlgorithmMismatch,
+			key.SigAlgo,
+			key.PublicKey.Algorithm(),
+		)
+	}
+
+	err := key.Validate()
+	if err != nil {
+		return err
+	}
+
+	hasher, err := key.Hasher()
+	if err != nil {
+		return err
+	}
+
+	valid, err := key.PublicKey.Verify(signature, message, hasher)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// DetectDuplicateKeys returns the indices of the keys whose public key appears earlier in
+// the given list, in increasing order. The first occurrence of each public key is not included.
+//
+// Public keys are compared by signature algorithm and encoding, so the same key with a
+// different weight or hash algorithm is a duplicate.
+//
+// An error is returned if a key or its public key is not set.
+func DetectDuplicateKeys(keys []*AccountKey) ([]int, error) {
+	seen := make(map[string]bool, len(keys))
+	duplicates := make([]int, 0)
+
+	for i, key := range keys {
+		if key == nil || key.PublicKey.Equal(crypto.PublicKey{}) {
+			return nil, fmt.Errorf("public key %d is not set", i)
+		}
+
+		id := fmt.Sprintf("%s/%x", key.PublicKey.Algorithm(), key.PublicKey.Encode())
+
+		if seen[id] {
+			duplicates = append(duplicates, i)
+			continue
+		}
+
+		seen[id] = true
+	}
+
+	return duplicates, nil
+}
+
+// DecodeAccountKey decodes the RLP byte representation of an account key produced by Encode.
+//
+// The ID and sequence number of the returned key are zero.
 func DecodeAccountKey(b []byte) (*AccountKey, error) {
 	var temp accountKeyWrapper
 
@@ -139,3 +389,67 @@ type accountKeyWrapper struct {
 	HashAlgo         uint
 	Weight           uint
 }
+
+// ErrAccountNotCreated indicates that no account creation event could be found for a pending account key.
+var ErrAccountNotCreated = errors.New("flow: no account created event found")
+
+// ErrMultipleAccountsCreated indicates that the account created for a pending account key is ambiguous,
+// because several account creation events were found.
+var ErrMultipleAccountsCreated = errors.New("flow: more than one account created event found")
+
+// A PendingAccountKey is a public key that has been generated for an account
+// that does not yet exist on the network.
+//
+// Account addresses are assigned by the network when an account is created, so the
+// address of the account for a key generated ahead of time (e.g. on a hardware wallet)
+// is only known once the account creation transaction has been executed.
+type PendingAccountKey struct {
+	PublicKey crypto.PublicKey
+}
+
+// NewPendingAccountKey returns a pending account key for the provided public key.
+func NewPendingAccountKey(publicKey crypto.PublicKey) *PendingAccountKey {
+	return &PendingAccountKey{PublicKey: publicKey}
+}
+
+// Resolve returns the address of the account that was created for this key.
+//
+// AccountCreated events do not include the keys of the new account, so the key cannot be
+// matched against the events. The provided events must be those emitted by the transaction
+// that created the account with this key, such as the events of its TransactionResult, and
+// that transaction must create a single account.
+//
+// ErrAccountNotCreated is returned if the events do not contain an AccountCreated event,
+// and ErrMultipleAccountsCreated if they contain more than one.
+func (p PendingAccountKey) Resolve(events []Event) (Address, error) {
+	address := ZeroAddress
+	found := false
+
+	for _, event := range events {
+		if event.Type != EventAccountCreated {
+			continue
+		}
+
+		if found {
+			return ZeroAddress, ErrMultipleAccountsCreated
+		}
+
+		if len(event.Value.Fields) == 0 {
+			return ZeroAddress, errors.Errorf("flow: malformed %s event", EventAccountCreated)
+		}
+
+		created, ok := event.Value.Fields[0].(cadence.Address)
+		if !ok {
+			return ZeroAddress, errors.Errorf("flow: malformed %s event", EventAccountCreated)
+		}
+
+		address = CadenceToAddress(created)
+		found = true
+	}
+
+	if !found {
+		return ZeroAddress, ErrAccountNotCreated
+	}
+
+	return address, nil
+}
@@ -0,0 +1,44 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// AccountProofNonceLength is the length in bytes of an account proof nonce.
+//
+// FCL requires the nonce of an account proof to be a hex string of at least 32 bytes.
+const AccountProofNonceLength = 32
+
+// GenerateAccountProofNonce returns a random hex-encoded nonce for an account proof challenge.
+//
+// The nonce is read from the system's cryptographically secure random number generator.
+func GenerateAccountProofNonce() (string, error) {
+	nonce := make([]byte, AccountProofNonceLength)
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate account proof nonce: %w", err)
+	}
+
+	return hex.EncodeToString(nonce), nil
+}
@@ -0,0 +1,44 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestGenerateAccountProofNonce(t *testing.T) {
+	nonce, err := flow.GenerateAccountProofNonce()
+	require.NoError(t, err)
+
+	assert.Len(t, nonce, 2*flow.AccountProofNonceLength)
+
+	b, err := hex.DecodeString(nonce)
+	require.NoError(t, err)
+	assert.Len(t, b, flow.AccountProofNonceLength)
+
+	other, err := flow.GenerateAccountProofNonce()
+	require.NoError(t, err)
+	assert.NotEqual(t, nonce, other)
+}
@@ -0,0 +1,364 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestAccount_ActiveKeysByWeight(t *testing.T) {
+	newKey := func(id, weight int) *flow.AccountKey {
+		key := flow.NewAccountKey().SetWeight(weight)
+		key.ID = id
+		return key
+	}
+
+	account := flow.Account{
+		Keys: []*flow.AccountKey{
+			newKey(0, 500),
+			newKey(1, 1000),
+			newKey(2, 250),
+			newKey(3, 1000),
+			newKey(4, 500),
+		},
+	}
+
+	keys := account.ActiveKeysByWeight()
+
+	ids := make([]int, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+
+	assert.Equal(t, []int{1, 3, 0, 4, 2}, ids)
+
+	// the account keys are not reordered
+	assert.Equal(t, 0, account.Keys[0].ID)
+	assert.Equal(t, 4, account.Keys[4].ID)
+}
+
+func TestPendingAccountKey_Resolve(t *testing.T) {
+	accountKey := test.AccountKeyGenerator().New()
+	pendingKey := flow.NewPendingAccountKey(accountKey.PublicKey)
+
+	address := test.AddressGenerator().New()
+
+	accountCreated := flow.Event{
+		Type: flow.EventAccountCreated,
+		Value: cadence.NewEvent([]cadence.Value{
+			cadence.NewAddressFromBytes(address.Bytes()),
+		}),
+	}
+
+	t.Run("Created", func(t *testing.T) {
+		result := flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Events: []flow.Event{
+				test.EventGenerator().New(),
+				accountCreated,
+			},
+		}
+
+		resolved, err := pendingKey.Resolve(result.Events)
+		require.NoError(t, err)
+
+		assert.Equal(t, address, resolved)
+	})
+
+	t.Run("Not created", func(t *testing.T) {
+		resolved, err := pendingKey.Resolve([]flow.Event{test.EventGenerator().New()})
+		assert.Equal(t, flow.ErrAccountNotCreated, err)
+		assert.Equal(t, flow.ZeroAddress, resolved)
+	})
+
+	t.Run("Several accounts created", func(t *testing.T) {
+		other := flow.Event{
+			Type: flow.EventAccountCreated,
+			Value: cadence.NewEvent([]cadence.Value{
+				cadence.NewAddressFromBytes(flow.RootAddress.Bytes()),
+			}),
+		}
+
+		resolved, err := pendingKey.Resolve([]flow.Event{accountCreated, other})
+		assert.Equal(t, flow.ErrMultipleAccountsCreated, err)
+		assert.Equal(t, flow.ZeroAddress, resolved)
+	})
+
+	t.Run("Malformed event", func(t *testing.T) {
+		malformed := flow.Event{
+			Type:  flow.EventAccountCreated,
+			Value: cadence.NewEvent([]cadence.Value{cadence.NewInt(42)}),
+		}
+
+		_, err := pendingKey.Resolve([]flow.Event{malformed})
+		assert.Error(t, err)
+	})
+}
+
+func TestAccountKey_Hasher(t *testing.T) {
+	t.Run("SHA2-256", func(t *testing.T) {
+		accountKey := flow.NewAccountKey().SetHashAlgo(crypto.SHA2_256)
+
+		hasher, err := accountKey.Hasher()
+		require.NoError(t, err)
+
+		message := []byte("foo")
+		assert.Equal(t, crypto.NewSHA2_256().ComputeHash(message), hasher.ComputeHash(message))
+	})
+
+	t.Run("SHA3-256", func(t *testing.T) {
+		accountKey := flow.NewAccountKey().SetHashAlgo(crypto.SHA3_256)
+
+		hasher, err := accountKey.Hasher()
+		require.NoError(t, err)
+
+		message := []byte("foo")
+		assert.Equal(t, crypto.NewSHA3_256().ComputeHash(message), hasher.ComputeHash(message))
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		accountKey := flow.NewAccountKey()
+
+		hasher, err := accountKey.Hasher()
+		assert.Error(t, err)
+		assert.Nil(t, hasher)
+	})
+}
+
+func TestAccountKey_MatchesPrivateKey(t *testing.T) {
+	privateKeyA, err := crypto.GeneratePrivateKey(
+		crypto.ECDSA_P256,
+		[]byte("elephant ears space cowboy octopus rodeo potato cannon pineapple"),
+	)
+	require.NoError(t, err)
+
+	privateKeyB, err := crypto.GeneratePrivateKey(
+		crypto.ECDSA_P256,
+		[]byte("pineapple cannon potato rodeo octopus cowboy space ears elephant"),
+	)
+	require.NoError(t, err)
+
+	key := flow.NewAccountKey().
+		FromPrivateKey(privateKeyA).
+		SetHashAlgo(crypto.SHA3_256)
+
+	assert.True(t, key.MatchesPrivateKey(privateKeyA))
+	assert.False(t, key.MatchesPrivateKey(privateKeyB))
+	assert.False(t, key.MatchesPrivateKey(crypto.PrivateKey{}))
+
+	mismatched := *key
+	mismatched.SigAlgo = crypto.ECDSA_secp256k1
+
+	assert.False(t, mismatched.MatchesPrivateKey(privateKeyA))
+}
+
+func TestDetectDuplicateKeys(t *testing.T) {
+	accountKeys := test.AccountKeyGenerator()
+
+	keyA := accountKeys.New()
+	keyB := accountKeys.New()
+
+	t.Run("Unique keys", func(t *testing.T) {
+		duplicates, err := flow.DetectDuplicateKeys([]*flow.AccountKey{keyA, keyB})
+		require.NoError(t, err)
+		assert.Empty(t, duplicates)
+	})
+
+	t.Run("Duplicate keys", func(t *testing.T) {
+		reweighted := *keyA
+		reweighted.Weight = 1
+
+		duplicates, err := flow.DetectDuplicateKeys([]*flow.AccountKey{keyA, keyB, &reweighted, keyB})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, duplicates)
+	})
+
+	t.Run("Missing public key", func(t *testing.T) {
+		_, err := flow.DetectDuplicateKeys([]*flow.AccountKey{keyA, flow.NewAccountKey()})
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyAgainstAllKeys(t *testing.T) {
+	accountKeys := test.AccountKeyGenerator()
+
+	signingKey, signer := accountKeys.NewWithSigner()
+	otherKey := accountKeys.New()
+
+	mismatchedKey := *signingKey
+	mismatchedKey.ID = 10
+	mismatchedKey.SigAlgo = crypto.ECDSA_secp256k1
+
+	incompatibleKey := *signingKey
+	incompatibleKey.ID = 11
+	incompatibleKey.HashAlgo = crypto.UnknownHashAlgorithm
+
+	emptyKey := flow.NewAccountKey()
+	emptyKey.ID = 12
+
+	message := []byte("hello world")
+
+	signature, err := signer.Sign(message)
+	require.NoError(t, err)
+
+	results := flow.VerifyAgainstAllKeys(
+		[]*flow.AccountKey{signingKey, otherKey, &mismatchedKey, &incompatibleKey, emptyKey},
+		message,
+		signature,
+	)
+	require.Len(t, results, 5)
+
+	assert.Equal(t, flow.KeyVerificationResult{KeyID: signingKey.ID, Verified: true}, results[0])
+
+	assert.Equal(t, otherKey.ID, results[1].KeyID)
+	assert.False(t, results[1].Verified)
+	assert.Equal(t, flow.ErrInvalidSignature, results[1].Err)
+
+	assert.Equal(t, 10, results[2].KeyID)
+	assert.False(t, results[2].Verified)
+	assert.True(t, errors.Is(results[2].Err, flow.ErrKeyAlgorithmMismatch))
+
+	assert.Equal(t, 11, results[3].KeyID)
+	assert.False(t, results[3].Verified)
+	assert.Error(t, results[3].Err)
+
+	assert.Equal(t, 12, results[4].KeyID)
+	assert.False(t, results[4].Verified)
+	assert.Error(t, results[4].Err)
+}
+
+func TestDiffAccounts(t *testing.T) {
+	accountKeys := test.AccountKeyGenerator()
+
+	keyA := accountKeys.New()
+	keyB := accountKeys.New()
+	keyC := accountKeys.New()
+
+	keyA.ID = 0
+	keyB.ID = 1
+	keyC.ID = 2
+
+	modifiedKeyB := *keyB
+	modifiedKeyB.Weight = keyB.Weight / 2
+
+	before := &flow.Account{
+		Address: test.AddressGenerator().New(),
+		Balance: 100,
+		Code:    []byte("pub contract Foo {}"),
+		Keys:    []*flow.AccountKey{keyA, keyB},
+	}
+
+	t.Run("Changes", func(t *testing.T) {
+		after := &flow.Account{
+			Address: before.Address,
+			Balance: 42,
+			Code:    []byte("pub contract Bar {}"),
+			Keys:    []*flow.AccountKey{keyC, &modifiedKeyB},
+		}
+
+		diff := flow.DiffAccounts(before, after)
+
+		assert.False(t, diff.IsEmpty())
+
+		assert.Equal(t, uint64(100), diff.BalanceBefore)
+		assert.Equal(t, uint64(42), diff.BalanceAfter)
+		assert.Equal(t, []*flow.AccountKey{keyC}, diff.AddedKeys)
+		assert.Equal(t, []*flow.AccountKey{keyA}, diff.RemovedKeys)
+		assert.Equal(t, []*flow.AccountKey{&modifiedKeyB}, diff.ModifiedKeys)
+		assert.True(t, diff.CodeChanged)
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		after := *before
+
+		diff := flow.DiffAccounts(before, &after)
+
+		assert.True(t, diff.IsEmpty())
+		assert.Empty(t, diff.AddedKeys)
+		assert.Empty(t, diff.RemovedKeys)
+		assert.Empty(t, diff.ModifiedKeys)
+	})
+
+	t.Run("Sequence number ignored", func(t *testing.T) {
+		proposedKeyA := *keyA
+		proposedKeyA.SequenceNumber = keyA.SequenceNumber + 1
+
+		after := &flow.Account{
+			Address: before.Address,
+			Balance: before.Balance,
+			Code:    before.Code,
+			Keys:    []*flow.AccountKey{&proposedKeyA, keyB},
+		}
+
+		diff := flow.DiffAccounts(before, after)
+
+		assert.True(t, diff.IsEmpty())
+		assert.Empty(t, diff.ModifiedKeys)
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		diff := flow.DiffAccounts(nil, before)
+
+		assert.Equal(t, uint64(0), diff.BalanceBefore)
+		assert.Equal(t, uint64(100), diff.BalanceAfter)
+		assert.Equal(t, []*flow.AccountKey{keyA, keyB}, diff.AddedKeys)
+		assert.True(t, diff.CodeChanged)
+	})
+}
+
+func TestAccountKey_Encode(t *testing.T) {
+	accountKey := test.AccountKeyGenerator().New()
+
+	t.Run("Known encoding", func(t *testing.T) {
+		expected := []byte{
+			248, 71, 184, 64, 199, 209, 247, 158, 141, 105, 106, 46, 33, 152, 142, 7, 81, 171, 181, 156,
+			100, 170, 60, 92, 218, 125, 250, 195, 229, 235, 105, 192, 11, 150, 121, 14, 251, 225, 162, 132,
+			64, 20, 237, 172, 176, 86, 201, 233, 29, 187, 31, 229, 168, 190, 133, 254, 90, 11, 87, 239,
+			249, 83, 170, 123, 0, 38, 93, 140, 2, 3, 130, 3, 232,
+		}
+
+		assert.Equal(t, expected, accountKey.Encode())
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		decoded, err := flow.DecodeAccountKey(accountKey.Encode())
+		require.NoError(t, err)
+
+		assert.Equal(t, accountKey.PublicKey.Encode(), decoded.PublicKey.Encode())
+		assert.Equal(t, accountKey.SigAlgo, decoded.SigAlgo)
+		assert.Equal(t, accountKey.HashAlgo, decoded.HashAlgo)
+		assert.Equal(t, accountKey.Weight, decoded.Weight)
+
+		assert.Equal(t, accountKey.Encode(), decoded.Encode())
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := flow.DecodeAccountKey([]byte{0xc1, 0x01})
+		assert.Error(t, err)
+	})
+}
@@ -19,11 +19,15 @@
 package flow
 
 import (
+	"encoding/binary"
 	"encoding/gob"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
 )
 
 const (
@@ -60,6 +64,16 @@ func HexToAddress(h string) Address {
 	return BytesToAddress(b)
 }
 
+// CadenceToAddress converts a Cadence address value to an Address.
+func CadenceToAddress(a cadence.Address) Address {
+	return Address(a)
+}
+
+// AddressToCadence converts an Address to a Cadence address value.
+func AddressToCadence(a Address) cadence.Address {
+	return cadence.Address(a)
+}
+
 // SetBytes sets this address to the value of b.
 //
 // If b is larger than len(a) it will panic.
@@ -101,3 +115,189 @@ func (a *Address) UnmarshalJSON(data []byte) error {
 	*a = HexToAddress(strings.Trim(string(data), "\""))
 	return nil
 }
+
+// Account addresses are code words of a [64,45] linear code, with a chain-specific
+// constant added to the code words of chains other than mainnet. A valid address
+// therefore occupies the low 8 bytes of an Address.
+const (
+	linearCodeN = 64
+	linearCodeK = 45
+
+	// maxAddressIndex is the index of the last address that can be generated
+	maxAddressIndex = (1 << linearCodeK) - 1
+
+	// invalid code words of the linear code, used to customize non-mainnet addresses
+	invalidCodeTestnet    = uint64(0x6834ba37b3980209)
+	invalidCodeEmulator   = uint64(0x1cb159857af02018)
+	invalidCodeSandboxnet = uint64(0x1035ce4eff92ae01)
+)
+
+// Columns of the parity-check matrix H of the [64,45] linear code used for account addresses.
+var parityCheckMatrixColumns = [linearCodeN]uint{
+	0x00001, 0x00002, 0x00004, 0x00008,
+	0x00010, 0x00020, 0x00040, 0x00080,
+	0x00100, 0x00200, 0x00400, 0x00800,
+	0x01000, 0x02000, 0x04000, 0x08000,
+	0x10000, 0x20000, 0x40000, 0x7328d,
+	0x6689a, 0x6112f, 0x6084b, 0x433fd,
+	0x42aab, 0x41951, 0x233ce, 0x22a81,
+	0x21948, 0x1ef60, 0x1deca, 0x1c639,
+	0x1bdd8, 0x1a535, 0x194ac, 0x18c46,
+	0x1632b, 0x1529b, 0x14a43, 0x13184,
+	0x12942, 0x118c1, 0x0f812, 0x0e027,
+	0x0d00e, 0x0c83c, 0x0b01d, 0x0a831,
+	0x0982b, 0x07034, 0x0682a, 0x05819,
+	0x03807, 0x007d2, 0x00727, 0x0068e,
+	0x0067c, 0x0059d, 0x004eb, 0x003b4,
+	0x0036a, 0x002d9, 0x001c7, 0x0003f,
+}
+
+// Rows of the generator matrix G of the [64,45] linear code used for account addresses.
+var generatorMatrixRows = [linearCodeK]uint64{
+	0xe467b9dd11fa00df, 0xf233dcee88fe0abe, 0xf919ee77447b7497, 0xfc8cf73ba23a260d,
+	0xfe467b9dd11ee2a1, 0xff233dcee888d807, 0xff919ee774476ce6, 0x7fc8cf73ba231d10,
+	0x3fe467b9dd11b183, 0x1ff233dcee8f96d6, 0x8ff919ee774757ba, 0x47fc8cf73ba2b331,
+	0x23fe467b9dd27f6c, 0x11ff233dceee8e82, 0x88ff919ee775dd8f, 0x447fc8cf73b905e4,
+	0xa23fe467b9de0d83, 0xd11ff233dce8d5a7, 0xe88ff919ee73c38a, 0x7447fc8cf73f171f,
+	0xba23fe467b9dcb2b, 0xdd11ff233dcb0cb4, 0xee88ff919ee26c5d, 0x77447fc8cf775dd3,
+	0x3ba23fe467b9b5a1, 0x9dd11ff233d9117a, 0xcee88ff919efa640, 0xe77447fc8cf3e297,
+	0x73ba23fe467fabd2, 0xb9dd11ff233fb16c, 0xdcee88ff919adde7, 0xee77447fc8ceb196,
+	0xf73ba23fe4621cd0, 0x7b9dd11ff2379ac3, 0x3dcee88ff91df46c, 0x9ee77447fc88e702,
+	0xcf73ba23fe4131b6, 0x67b9dd11ff240f9a, 0x33dcee88ff90f9e0, 0x19ee77447fcff4e3,
+	0x8cf73ba23fe64091, 0x467b9dd11ff115c7, 0x233dcee88ffdb735, 0x919ee77447fe2309,
+	0xc8cf73ba23fdc736,
+}
+
+// chainCodeWord returns the constant added to the address code words of the given chain.
+func chainCodeWord(chainID ChainID) (uint64, bool) {
+	switch chainID {
+	case Mainnet:
+		return 0, true
+	case Testnet:
+		return invalidCodeTestnet, true
+	case Emulator:
+		return invalidCodeEmulator, true
+	case Sandboxnet:
+		return invalidCodeSandboxnet, true
+	default:
+		return 0, false
+	}
+}
+
+// ValidateAddressChecksum returns true if the address is a valid account address
+// on the given chain, and false otherwise.
+//
+// This is an offline check of the linear-code parity of the address. It does not
+// check whether an account with this address has been created on the chain.
+// False is returned for an unknown chain ID.
+func ValidateAddressChecksum(address Address, chainID ChainID) bool {
+	customizer, ok := chainCodeWord(chainID)
+	if !ok {
+		return false
+	}
+
+	const codeWordOffset = AddressLength - linearCodeN/8
+
+	// bytes above the code word must be zero
+	for _, b := range address[:codeWordOffset] {
+		if b != 0 {
+			return false
+		}
+	}
+
+	codeWord := binary.BigEndian.Uint64(address[codeWordOffset:]) ^ customizer
+
+	// the zero code word is reserved for the zero address
+	if codeWord == 0 {
+		return false
+	}
+
+	// multiply the code word by the parity-check matrix
+	parity := uint(0)
+	for i := 0; i < linearCodeN; i++ {
+		if codeWord&1 == 1 {
+			parity ^= parityCheckMatrixColumns[i]
+		}
+		codeWord >>= 1
+	}
+
+	return parity == 0
+}
+
+// IsValid returns true if this address is a valid account address on the given chain.
+//
+// It is equivalent to ValidateAddressChecksum.
+func (a Address) IsValid(chainID ChainID) bool {
+	return ValidateAddressChecksum(a, chainID)
+}
+
+// An AddressGenerator generates the sequence of account addresses of a chain,
+// in the order in which the chain assigns them to new accounts.
+//
+// An AddressGenerator is safe for concurrent use.
+type AddressGenerator struct {
+	mu         sync.Mutex
+	customizer uint64
+	index      uint64
+}
+
+// NewAddressGenerator returns a generator for the account addresses of the given chain.
+//
+// The first generated address is the address of the service account.
+// An error is returned for an unknown chain ID.
+func NewAddressGenerator(chainID ChainID) (*AddressGenerator, error) {
+	customizer, ok := chainCodeWord(chainID)
+	if !ok {
+		return nil, fmt.Errorf("unknown chain ID %s", chainID)
+	}
+
+	return &AddressGenerator{customizer: customizer}, nil
+}
+
+// Next returns the next address in the sequence.
+//
+// Next panics if all addresses of the chain have been generated.
+func (g *AddressGenerator) Next() Address {
+	return g.Reserve(1)[0]
+}
+
+// Reserve atomically reserves the next n addresses in the sequence and returns them in order.
+//
+// Reserve panics if fewer than n addresses of the chain remain.
+func (g *AddressGenerator) Reserve(n int) []Address {
+	if n <= 0 {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if uint64(n) > maxAddressIndex-g.index {
+		panic(fmt.Sprintf("cannot reserve %d addresses: only %d remain", n, maxAddressIndex-g.index))
+	}
+
+	addresses := make([]Address, n)
+	for i := range addresses {
+		g.index++
+		addresses[i] = generateAddress(g.index, g.customizer)
+	}
+
+	return addresses
+}
+
+// generateAddress returns the account address at the given index of the address sequence.
+func generateAddress(index, customizer uint64) Address {
+	// multiply the index by the generator matrix
+	codeWord := uint64(0)
+	for i := 0; i < linearCodeK; i++ {
+		if index&1 == 1 {
+			codeWord ^= generatorMatrixRows[i]
+		}
+		index >>= 1
+	}
+
+	var address Address
+	binary.BigEndian.PutUint64(address[AddressLength-linearCodeN/8:], codeWord^customizer)
+
+	return address
+}
@@ -20,9 +20,11 @@ package flow_test
 
 import (
 	"encoding/json"
+	"sync"
 	"testing"
 
 	"github.com/magiconair/properties/assert"
+	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk"
@@ -72,3 +74,187 @@ func TestAddress_Short(t *testing.T) {
 		assert.Equal(t, c.addr.Short(), c.expected)
 	}
 }
+
+func TestValidateAddressChecksum(t *testing.T) {
+	validAddresses := map[flow.ChainID][]string{
+		flow.Mainnet: {
+			"e467b9dd11fa00df",
+			"f233dcee88fe0abe",
+			"1654653399040a61",
+		},
+		flow.Testnet: {
+			"8c5303eaa26202d6",
+			"9a0766d93b6608b7",
+			"7e60df042a9c0868",
+		},
+		flow.Emulator: {
+			"f8d6e0586b0a20c7",
+			"ee82856bf20e2aa6",
+			"0ae53cb6e3f42a79",
+		},
+		flow.Sandboxnet: {
+			"f4527793ee68aede",
+			"e20612a0776ca4bf",
+			"0661ab7d6696a460",
+		},
+	}
+
+	for chainID, addresses := range validAddresses {
+		chainID, addresses := chainID, addresses
+
+		t.Run(chainID.String(), func(t *testing.T) {
+			for _, hex := range addresses {
+				address := flow.HexToAddress(hex)
+
+				require.True(t, flow.ValidateAddressChecksum(address, chainID), hex)
+
+				// flip each bit of the code word
+				for i := flow.AddressLength - 8; i < flow.AddressLength; i++ {
+					for bit := uint(0); bit < 8; bit++ {
+						corrupted := address
+						corrupted[i] ^= 1 << bit
+
+						require.False(t, flow.ValidateAddressChecksum(corrupted, chainID), corrupted.Hex())
+					}
+				}
+
+				// bytes above the code word must be zero
+				extended := address
+				extended[0] = 1
+				require.False(t, flow.ValidateAddressChecksum(extended, chainID), extended.Hex())
+
+				// addresses are not valid on other chains
+				for otherChainID := range validAddresses {
+					if otherChainID != chainID {
+						require.False(t, flow.ValidateAddressChecksum(address, otherChainID), hex)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Zero address", func(t *testing.T) {
+		require.False(t, flow.ValidateAddressChecksum(flow.ZeroAddress, flow.Mainnet))
+	})
+
+	t.Run("Unknown chain", func(t *testing.T) {
+		require.False(t, flow.ValidateAddressChecksum(flow.HexToAddress("e467b9dd11fa00df"), "flow-unknown"))
+	})
+}
+
+func TestCadenceToAddress(t *testing.T) {
+	cadenceAddress := cadence.BytesToAddress([]byte{0x01, 0x02, 0x03})
+
+	address := flow.CadenceToAddress(cadenceAddress)
+	require.Equal(t, flow.HexToAddress("010203"), address)
+
+	require.Equal(t, cadenceAddress, flow.AddressToCadence(address))
+}
+
+func TestAddressToCadence(t *testing.T) {
+	address := flow.HexToAddress("f233dcee88fe0abe")
+
+	cadenceAddress := flow.AddressToCadence(address)
+	require.Equal(t, address.Bytes(), cadenceAddress.Bytes())
+
+	require.Equal(t, address, flow.CadenceToAddress(cadenceAddress))
+}
+
+func TestAddress_IsValid(t *testing.T) {
+	chains := []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Emulator, flow.Sandboxnet}
+
+	for _, chainID := range chains {
+		gen, err := flow.NewAddressGenerator(chainID)
+		require.NoError(t, err)
+
+		address := gen.Next()
+
+		for _, other := range chains {
+			assert.Equal(t, address.IsValid(other), other == chainID, address.Hex()+" on "+other.String())
+		}
+	}
+
+	assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7").IsValid("flow-unknown"), false)
+}
+
+func TestAddressGenerator(t *testing.T) {
+	t.Run("Sequence", func(t *testing.T) {
+		chains := map[flow.ChainID][]string{
+			flow.Mainnet:    {"e467b9dd11fa00df", "f233dcee88fe0abe", "1654653399040a61"},
+			flow.Testnet:    {"8c5303eaa26202d6", "9a0766d93b6608b7", "7e60df042a9c0868"},
+			flow.Emulator:   {"f8d6e0586b0a20c7", "ee82856bf20e2aa6", "0ae53cb6e3f42a79"},
+			flow.Sandboxnet: {"f4527793ee68aede", "e20612a0776ca4bf", "0661ab7d6696a460"},
+		}
+
+		for chainID, expected := range chains {
+			gen, err := flow.NewAddressGenerator(chainID)
+			require.NoError(t, err)
+
+			for _, hex := range expected {
+				address := gen.Next()
+
+				assert.Equal(t, address, flow.HexToAddress(hex), chainID.String())
+				require.True(t, flow.ValidateAddressChecksum(address, chainID))
+			}
+		}
+	})
+
+	t.Run("Unknown chain", func(t *testing.T) {
+		_, err := flow.NewAddressGenerator("flow-unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("Reserve", func(t *testing.T) {
+		gen, err := flow.NewAddressGenerator(flow.Emulator)
+		require.NoError(t, err)
+
+		reserved := gen.Reserve(2)
+		require.Len(t, reserved, 2)
+
+		assert.Equal(t, reserved[0], flow.HexToAddress("f8d6e0586b0a20c7"))
+		assert.Equal(t, reserved[1], flow.HexToAddress("ee82856bf20e2aa6"))
+		assert.Equal(t, gen.Next(), flow.HexToAddress("0ae53cb6e3f42a79"))
+
+		require.Empty(t, gen.Reserve(0))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		gen, err := flow.NewAddressGenerator(flow.Testnet)
+		require.NoError(t, err)
+
+		const (
+			goroutines = 16
+			batches    = 50
+			batchSize  = 4
+		)
+
+		results := make(chan flow.Address, goroutines*batches*batchSize)
+
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < batches; j++ {
+					for _, address := range gen.Reserve(batchSize) {
+						results <- address
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(results)
+
+		seen := make(map[flow.Address]bool)
+		for address := range results {
+			require.False(t, seen[address], "duplicate address %s", address)
+			require.True(t, flow.ValidateAddressChecksum(address, flow.Testnet))
+
+			seen[address] = true
+		}
+
+		require.Len(t, seen, goroutines*batches*batchSize)
+	})
+}
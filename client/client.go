@@ -20,7 +20,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -28,6 +33,9 @@ import (
 
 	"github.com/onflow/cadence"
 	encoding "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/flow/protobuf/go/flow/access"
 
 	"github.com/onflow/flow-go-sdk"
@@ -39,49 +47,113 @@ type RPCClient interface {
 	access.AccessAPIClient
 }
 
+// ErrUnsupportedByNode indicates that the access node does not implement an RPC method,
+// usually because it runs an older version of the Access API.
+//
+// Callers can detect it with errors.As and fall back to other methods.
+type ErrUnsupportedByNode struct {
+	// Method is the name of the Access API method that is not implemented.
+	Method string
+}
+
+func (e ErrUnsupportedByNode) Error() string {
+	return fmt.Sprintf("access node does not support %s", e.Method)
+}
+
+// unsupportedByNode converts an Unimplemented gRPC error returned by the given method
+// into an ErrUnsupportedByNode error. Other errors are returned unchanged.
+func unsupportedByNode(method string, err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return ErrUnsupportedByNode{Method: method}
+	}
+
+	return err
+}
+
+// isUnsupportedByNode returns true if err is an ErrUnsupportedByNode error.
+func isUnsupportedByNode(err error) bool {
+	var unsupported ErrUnsupportedByNode
+	return errors.As(err, &unsupported)
+}
+
 // A Client is a gRPC Client for the Flow Access API.
+//
+// The context passed to each Client method is passed unmodified to the underlying RPC,
+// so values added with context.WithValue are visible to any client interceptors
+// registered with grpc.WithUnaryInterceptor or grpc.WithChainUnaryInterceptor.
 type Client struct {
 	rpcClient RPCClient
+	conn      connectionStateWatcher
 	close     func() error
+	// ctx is cancelled when the client is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newClient(rpcClient RPCClient, conn connectionStateWatcher, close func() error) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Client{
+		rpcClient: rpcClient,
+		conn:      conn,
+		close:     close,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
 }
 
 // New initializes a Flow client with the default gRPC provider.
 //
 // An error will be returned if the host is unreachable.
+//
+// If the WithDialTimeout option is provided, New waits for the connection to be
+// established and returns an error wrapping context.DeadlineExceeded if it is not
+// established in time.
 func New(addr string, opts ...grpc.DialOption) (*Client, error) {
-	conn, err := grpc.Dial(addr, opts...)
+	ctx := context.Background()
+
+	timeout, hasTimeout := dialTimeout(opts)
+	if hasTimeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, opts...)
 	if err != nil {
+		if hasTimeout && errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("client: failed to connect to %s within %s: %w", addr, timeout, err)
+		}
+
 		return nil, err
 	}
 
 	grpcClient := access.NewAccessAPIClient(conn)
 
-	return &Client{
-		rpcClient: grpcClient,
-		close:     func() error { return conn.Close() },
-	}, nil
+	return newClient(grpcClient, conn, conn.Close), nil
 }
 
 // NewFromRPCClient initializes a Flow client using a pre-configured gRPC provider.
 func NewFromRPCClient(rpcClient RPCClient) *Client {
-	return &Client{
-		rpcClient: rpcClient,
-		close:     func() error { return nil },
-	}
+	return newClient(rpcClient, nil, func() error { return nil })
 }
 
 // Close closes the client connection.
 func (c *Client) Close() error {
+	c.cancel()
 	return c.close()
 }
 
 // Ping is used to check if the access node is alive and healthy.
 func (c *Client) Ping(ctx context.Context) error {
 	_, err := c.rpcClient.Ping(ctx, &access.PingRequest{})
-	return err
+	return unsupportedByNode("Ping", err)
 }
 
 // GetLatestBlockHeader gets the latest sealed or unsealed block header.
+//
+// If the access node does not support block header requests, the header is taken from
+// the full block instead.
 func (c *Client) GetLatestBlockHeader(
 	ctx context.Context,
 	isSealed bool,
@@ -92,6 +164,11 @@ func (c *Client) GetLatestBlockHeader(
 
 	res, err := c.rpcClient.GetLatestBlockHeader(ctx, req)
 	if err != nil {
+		err = unsupportedByNode("GetLatestBlockHeader", err)
+		if isUnsupportedByNode(err) {
+			return blockHeaderFromBlock(c.GetLatestBlock(ctx, isSealed))
+		}
+
 		// TODO: improve errors
 		return nil, fmt.Errorf("client: %w", err)
 	}
@@ -100,6 +177,9 @@ func (c *Client) GetLatestBlockHeader(
 }
 
 // GetBlockHeaderByID gets a block header by ID.
+//
+// If the access node does not support block header requests, the header is taken from
+// the full block instead.
 func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
 	req := &access.GetBlockHeaderByIDRequest{
 		Id: blockID.Bytes(),
@@ -107,6 +187,11 @@ func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier
 
 	res, err := c.rpcClient.GetBlockHeaderByID(ctx, req)
 	if err != nil {
+		err = unsupportedByNode("GetBlockHeaderByID", err)
+		if isUnsupportedByNode(err) {
+			return blockHeaderFromBlock(c.GetBlockByID(ctx, blockID))
+		}
+
 		// TODO: improve errors
 		return nil, fmt.Errorf("client: %w", err)
 	}
@@ -115,6 +200,9 @@ func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier
 }
 
 // GetBlockHeaderByHeight gets a block header by height.
+//
+// If the access node does not support block header requests, the header is taken from
+// the full block instead.
 func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
 	req := &access.GetBlockHeaderByHeightRequest{
 		Height: height,
@@ -122,6 +210,11 @@ func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*fl
 
 	res, err := c.rpcClient.GetBlockHeaderByHeight(ctx, req)
 	if err != nil {
+		err = unsupportedByNode("GetBlockHeaderByHeight", err)
+		if isUnsupportedByNode(err) {
+			return blockHeaderFromBlock(c.GetBlockByHeight(ctx, height))
+		}
+
 		// TODO: improve errors
 		return nil, fmt.Errorf("client: %w", err)
 	}
@@ -129,6 +222,15 @@ func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*fl
 	return getBlockHeaderResult(res)
 }
 
+// blockHeaderFromBlock returns the header of a block fetched in place of its header.
+func blockHeaderFromBlock(block *flow.Block, err error) (*flow.BlockHeader, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &block.BlockHeader, nil
+}
+
 func getBlockHeaderResult(res *access.BlockHeaderResponse) (*flow.BlockHeader, error) {
 	result, err := convert.MessageToBlockHeader(res.GetBlock())
 	if err != nil {
@@ -148,7 +250,7 @@ func (c *Client) GetLatestBlock(ctx context.Context, isSealed bool) (*flow.Block
 	res, err := c.rpcClient.GetLatestBlock(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetLatestBlock", err))
 	}
 
 	return getBlockResult(res)
@@ -163,7 +265,7 @@ func (c *Client) GetBlockByID(ctx context.Context, blockID flow.Identifier) (*fl
 	res, err := c.rpcClient.GetBlockByID(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetBlockByID", err))
 	}
 
 	return getBlockResult(res)
@@ -178,7 +280,7 @@ func (c *Client) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Blo
 	res, err := c.rpcClient.GetBlockByHeight(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetBlockByHeight", err))
 	}
 
 	return getBlockResult(res)
@@ -203,7 +305,7 @@ func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flo
 	res, err := c.rpcClient.GetCollectionByID(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetCollectionByID", err))
 	}
 
 	result, err := convert.MessageToCollection(res.GetCollection())
@@ -224,12 +326,51 @@ func (c *Client) SendTransaction(ctx context.Context, transaction flow.Transacti
 	_, err := c.rpcClient.SendTransaction(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return fmt.Errorf("client: %w", err)
+		return fmt.Errorf("client: %w", unsupportedByNode("SendTransaction", err))
 	}
 
 	return nil
 }
 
+// maxConcurrentSends is the maximum number of transactions submitted at once by SendTransactions.
+const maxConcurrentSends = 10
+
+// SendTransactions submits a batch of transactions to the network.
+//
+// Transactions are submitted concurrently, and the returned IDs and errors are
+// aligned with the order of the provided transactions. The ID of a transaction that
+// failed to send is flow.ZeroID. Submissions are never retried, as sending a transaction
+// is not idempotent.
+func (c *Client) SendTransactions(ctx context.Context, txs []*flow.Transaction) ([]flow.Identifier, []error) {
+	ids := make([]flow.Identifier, len(txs))
+	errs := make([]error, len(txs))
+
+	sem := make(chan struct{}, maxConcurrentSends)
+
+	var wg sync.WaitGroup
+
+	for i, tx := range txs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, tx *flow.Transaction) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			errs[i] = c.SendTransaction(ctx, *tx)
+			if errs[i] == nil {
+				ids[i] = tx.ID()
+			}
+		}(i, tx)
+	}
+
+	wg.Wait()
+
+	return ids, errs
+}
+
 // GetTransaction gets a transaction by ID.
 func (c *Client) GetTransaction(ctx context.Context, txID flow.Identifier) (*flow.Transaction, error) {
 	req := &access.GetTransactionRequest{
@@ -239,7 +380,7 @@ func (c *Client) GetTransaction(ctx context.Context, txID flow.Identifier) (*flo
 	res, err := c.rpcClient.GetTransaction(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetTransaction", err))
 	}
 
 	result, err := convert.MessageToTransaction(res.GetTransaction())
@@ -251,6 +392,41 @@ func (c *Client) GetTransaction(ctx context.Context, txID flow.Identifier) (*flo
 	return &result, nil
 }
 
+// averageBlockTime is the approximate time between blocks on the Flow networks.
+const averageBlockTime = time.Second
+
+// TransactionValidityWindow returns the number of blocks remaining before a transaction expires,
+// and the approximate time until it expires.
+//
+// A transaction expires flow.DefaultTransactionExpiry blocks after its reference block.
+// The remaining blocks are counted from the latest sealed block, and the remaining time
+// assumes an average block time of one second.
+//
+// If the transaction has already expired, zero blocks and zero time are returned.
+func (c *Client) TransactionValidityWindow(
+	ctx context.Context,
+	tx *flow.Transaction,
+) (uint64, time.Duration, error) {
+	referenceBlock, err := c.GetBlockHeaderByID(ctx, tx.ReferenceBlockID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	latestBlock, err := c.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	expiryHeight := referenceBlock.Height + flow.DefaultTransactionExpiry
+	if latestBlock.Height >= expiryHeight {
+		return 0, 0, nil
+	}
+
+	blocksRemaining := expiryHeight - latestBlock.Height
+
+	return blocksRemaining, time.Duration(blocksRemaining) * averageBlockTime, nil
+}
+
 // GetTransactionResult gets the result of a transaction.
 func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
 	req := &access.GetTransactionRequest{
@@ -260,7 +436,7 @@ func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier)
 	res, err := c.rpcClient.GetTransactionResult(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetTransactionResult", err))
 	}
 
 	result, err := convert.MessageToTransactionResult(res)
@@ -272,6 +448,30 @@ func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier)
 	return &result, nil
 }
 
+// GetTransactionEvents gets the events emitted by a transaction, in the order they were emitted.
+//
+// Events are only returned once the transaction is sealed. An empty slice is returned
+// for a transaction that has not yet been sealed.
+func (c *Client) GetTransactionEvents(ctx context.Context, txID flow.Identifier) ([]flow.Event, error) {
+	result, err := c.GetTransactionResult(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status != flow.TransactionStatusSealed {
+		return []flow.Event{}, nil
+	}
+
+	events := make([]flow.Event, len(result.Events))
+	copy(events, result.Events)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventIndex < events[j].EventIndex
+	})
+
+	return events, nil
+}
+
 // GetAccount gets an account by address.
 func (c *Client) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
 	res, err := c.rpcClient.GetAccount(
@@ -279,7 +479,7 @@ func (c *Client) GetAccount(ctx context.Context, address flow.Address) (*flow.Ac
 		&access.GetAccountRequest{Address: address.Bytes()},
 	)
 	if err != nil {
-		return nil, err
+		return nil, unsupportedByNode("GetAccount", err)
 	}
 
 	account, err := convert.MessageToAccount(res.GetAccount())
@@ -290,11 +490,257 @@ func (c *Client) GetAccount(ctx context.Context, address flow.Address) (*flow.Ac
 	return &account, nil
 }
 
+// CheckTransactionAuthorization checks whether a transaction is signed with enough key weight
+// to be accepted by the network.
+//
+// The accounts of the proposer, payer, authorizers and signers are fetched from the access node
+// and the transaction is complete if:
+//   - the proposal key has signed the transaction,
+//   - the payer has signed the envelope with a total weight of at least flow.AccountKeyWeightThreshold, and
+//   - every authorizer other than the payer has signed the payload with a total weight of at
+//     least flow.AccountKeyWeightThreshold.
+//
+// If the transaction is not complete, a human-readable reason is returned for every problem found.
+// An invalid signature or a signature from an unknown key is reported as a reason, and the
+// remaining checks are then made without counting any signature weight.
+// An error is returned only if an account cannot be fetched.
+func (c *Client) CheckTransactionAuthorization(ctx context.Context, tx *flow.Transaction) (bool, []string, error) {
+	addresses := make([]flow.Address, 0)
+	seen := make(map[flow.Address]struct{})
+
+	addAddress := func(address flow.Address) {
+		if _, ok := seen[address]; ok {
+			return
+		}
+
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	addAddress(tx.ProposalKey.Address)
+	addAddress(tx.Payer)
+
+	for _, authorizer := range tx.Authorizers {
+		addAddress(authorizer)
+	}
+
+	signatures := make([]flow.TransactionSignature, 0, len(tx.PayloadSignatures)+len(tx.EnvelopeSignatures))
+	signatures = append(signatures, tx.PayloadSignatures...)
+	signatures = append(signatures, tx.EnvelopeSignatures...)
+
+	for _, sig := range signatures {
+		addAddress(sig.Address)
+	}
+
+	accounts := make(map[flow.Address][]*flow.AccountKey, len(addresses))
+
+	for _, address := range addresses {
+		account, err := c.GetAccount(ctx, address)
+		if err != nil {
+			return false, nil, fmt.Errorf("client: failed to get account %s: %w", address, err)
+		}
+
+		accounts[address] = account.Keys
+	}
+
+	reasons := make([]string, 0)
+
+	// an invalid signature is reported, and the remaining checks are made without any signature weight
+	payloadWeights, envelopeWeights, err := tx.SignatureWeights(accounts)
+	if err != nil {
+		reasons = append(reasons, err.Error())
+		payloadWeights = make(map[flow.Address]int)
+		envelopeWeights = make(map[flow.Address]int)
+	}
+
+	proposerSigned := false
+	for _, sig := range signatures {
+		if sig.Address == tx.ProposalKey.Address && sig.KeyID == tx.ProposalKey.KeyID {
+			proposerSigned = true
+			break
+		}
+	}
+
+	if !proposerSigned {
+		reasons = append(reasons, fmt.Sprintf(
+			"proposal key %d on account %s has not signed",
+			tx.ProposalKey.KeyID,
+			tx.ProposalKey.Address,
+		))
+	}
+
+	payerSigned := false
+	for _, sig := range tx.EnvelopeSignatures {
+		if sig.Address == tx.Payer {
+			payerSigned = true
+			break
+		}
+	}
+
+	if !payerSigned {
+		reasons = append(reasons, fmt.Sprintf("payer %s has not signed the envelope", tx.Payer))
+	} else if envelopeWeights[tx.Payer] < flow.AccountKeyWeightThreshold {
+		reasons = append(reasons, fmt.Sprintf(
+			"payer %s has insufficient signature weight %d, requires %d",
+			tx.Payer,
+			envelopeWeights[tx.Payer],
+			flow.AccountKeyWeightThreshold,
+		))
+	}
+
+	for _, authorizer := range tx.Authorizers {
+		// an authorizer that is also the payer is authorized by its envelope signatures
+		if authorizer == tx.Payer {
+			continue
+		}
+
+		if payloadWeights[authorizer] < flow.AccountKeyWeightThreshold {
+			reasons = append(reasons, fmt.Sprintf(
+				"authorizer %s has insufficient signature weight %d, requires %d",
+				authorizer,
+				payloadWeights[authorizer],
+				flow.AccountKeyWeightThreshold,
+			))
+		}
+	}
+
+	return len(reasons) == 0, reasons, nil
+}
+
 // ExecuteScriptAtLatestBlock executes a read-only Cadence script against the latest sealed execution state.
+//
+// The deadline of ctx, if any, is sent to the access node as the gRPC timeout of the call,
+// allowing the node to stop executing the script once the caller is no longer waiting for it.
+// This function returns as soon as ctx is done, even if the node has not yet responded.
 func (c *Client) ExecuteScriptAtLatestBlock(ctx context.Context, script []byte) (cadence.Value, error) {
-	res, err := c.rpcClient.ExecuteScriptAtLatestBlock(ctx, &access.ExecuteScriptAtLatestBlockRequest{Script: script})
+	return executeScript(ctx, func() (*access.ExecuteScriptResponse, error) {
+		res, err := c.rpcClient.ExecuteScriptAtLatestBlock(ctx, &access.ExecuteScriptAtLatestBlockRequest{Script: script})
+		return res, unsupportedByNode("ExecuteScriptAtLatestBlock", err)
+	})
+}
+
+// ExecuteScriptExpectType executes a read-only Cadence script against the latest sealed
+// execution state and returns an error if the result is not of the expected type.
+//
+// Composite values are matched by type ID, and the values of optionals, arrays and
+// dictionaries are matched against their element types.
+//
+// Script arguments are not supported by the Access API, so args must be empty;
+// values should instead be interpolated into the script.
+func (c *Client) ExecuteScriptExpectType(
+	ctx context.Context,
+	script []byte,
+	args []cadence.Value,
+	expected cadence.Type,
+) (cadence.Value, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("client: script arguments are not supported by the Access API")
+	}
+
+	value, err := c.ExecuteScriptAtLatestBlock(ctx, script)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	if !valueHasType(value, expected) {
+		return nil, fmt.Errorf("client: script returned %v, which is not of type %s", value, expected.ID())
+	}
+
+	return value, nil
+}
+
+// valueHasType returns true if the value conforms to the given type.
+func valueHasType(value cadence.Value, t cadence.Type) bool {
+	switch t := t.(type) {
+	case cadence.AnyType, cadence.AnyStructType, cadence.AnyResourceType:
+		return true
+
+	case cadence.OptionalType:
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return false
+		}
+
+		return optional.Value == nil || valueHasType(optional.Value, t.Type)
+
+	case cadence.VariableSizedArrayType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+
+		return valuesHaveType(array.Values, t.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		array, ok := value.(cadence.Array)
+		if !ok || uint(len(array.Values)) != t.Size {
+			return false
+		}
+
+		return valuesHaveType(array.Values, t.ElementType)
+
+	case cadence.DictionaryType:
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return false
+		}
+
+		for _, pair := range dictionary.Pairs {
+			if !valueHasType(pair.Key, t.KeyType) || !valueHasType(pair.Value, t.ElementType) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		valueType := value.Type()
+		return valueType != nil && valueType.ID() == t.ID()
+	}
+}
+
+func valuesHaveType(values []cadence.Value, t cadence.Type) bool {
+	for _, value := range values {
+		if !valueHasType(value, t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// executeScript performs a script execution call and decodes its result.
+//
+// The call is made in a separate goroutine so that the result is abandoned as soon as
+// ctx is done, rather than when the underlying RPC returns.
+func executeScript(
+	ctx context.Context,
+	call func() (*access.ExecuteScriptResponse, error),
+) (cadence.Value, error) {
+	type result struct {
+		res *access.ExecuteScriptResponse
+		err error
+	}
+
+	// buffered so that the goroutine can exit if the result is abandoned
+	results := make(chan result, 1)
+
+	go func() {
+		res, err := call()
+		results <- result{res, err}
+	}()
+
+	var res *access.ExecuteScriptResponse
+
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case r := <-results:
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		res = r.res
 	}
 
 	value, err := encoding.Decode(res.GetValue())
@@ -304,14 +750,172 @@ func (c *Client) ExecuteScriptAtLatestBlock(ctx context.Context, script []byte)
 	return value, nil
 }
 
-// ExecuteScriptAtBlockID executes a ready-only Cadence script against the execution state at the block with the given ID.
-func (c *Client) ExecuteScriptAtBlockID(ctx context.Context) error {
-	panic("not implemented")
+// ExecuteScriptAtBlockID executes a read-only Cadence script against the execution state at the block with the given ID.
+//
+// Like ExecuteScriptAtLatestBlock, this function returns as soon as ctx is done.
+// Script arguments are not supported by the Access API, so values should be interpolated into the script.
+func (c *Client) ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte) (cadence.Value, error) {
+	return executeScript(ctx, func() (*access.ExecuteScriptResponse, error) {
+		res, err := c.rpcClient.ExecuteScriptAtBlockID(ctx, &access.ExecuteScriptAtBlockIDRequest{
+			BlockId: blockID.Bytes(),
+			Script:  script,
+		})
+		return res, unsupportedByNode("ExecuteScriptAtBlockID", err)
+	})
+}
+
+// ExecuteScriptAtBlockHeight executes a read-only Cadence script against the execution state at the given block height.
+//
+// Like ExecuteScriptAtLatestBlock, this function returns as soon as ctx is done.
+// Script arguments are not supported by the Access API, so values should be interpolated into the script.
+func (c *Client) ExecuteScriptAtBlockHeight(ctx context.Context, height uint64, script []byte) (cadence.Value, error) {
+	return executeScript(ctx, func() (*access.ExecuteScriptResponse, error) {
+		res, err := c.rpcClient.ExecuteScriptAtBlockHeight(ctx, &access.ExecuteScriptAtBlockHeightRequest{
+			BlockHeight: height,
+			Script:      script,
+		})
+		return res, unsupportedByNode("ExecuteScriptAtBlockHeight", err)
+	})
 }
 
-// ExecuteScriptAtBlockHeight executes a ready-only Cadence script against the execution state at the given block height.
-func (c *Client) ExecuteScriptAtBlockHeight(ctx context.Context) error {
-	panic("not implemented")
+const getFeeParametersScript = `
+import FlowFees from 0x%s
+
+pub fun main(): [UFix64] {
+  let params = FlowFees.getFeeParameters()
+  return [params.surgeFactor, params.inclusionEffortFactor, params.executionEffortFactor]
+}
+`
+
+// GetFeeParameters gets the current transaction fee parameters from the FlowFees contract
+// deployed at the given address.
+func (c *Client) GetFeeParameters(ctx context.Context, feesAddress flow.Address) (*flow.FeeParameters, error) {
+	script := []byte(fmt.Sprintf(getFeeParametersScript, feesAddress.Short()))
+
+	value, err := c.ExecuteScriptAtLatestBlock(ctx, script)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok || len(array.Values) != 3 {
+		return nil, fmt.Errorf("client: unexpected fee parameters value %v", value)
+	}
+
+	params := make([]uint64, len(array.Values))
+	for i, v := range array.Values {
+		param, ok := v.(cadence.UFix64)
+		if !ok {
+			return nil, fmt.Errorf("client: unexpected fee parameter value %v", v)
+		}
+
+		params[i] = uint64(param)
+	}
+
+	return &flow.FeeParameters{
+		SurgeFactor:           params[0],
+		InclusionEffortFactor: params[1],
+		ExecutionEffortFactor: params[2],
+	}, nil
+}
+
+// EstimateTransactionFee returns the maximum fee that can be charged for a transaction,
+// using the current fee parameters of the FlowFees contract deployed at the given address.
+//
+// The fee is returned as a UFix64 fixed-point number, scaled by flow.UFix64Factor.
+// See flow.EstimateTransactionFee for how the fee is estimated.
+func (c *Client) EstimateTransactionFee(
+	ctx context.Context,
+	tx *flow.Transaction,
+	feesAddress flow.Address,
+) (uint64, error) {
+	params, err := c.GetFeeParameters(ctx, feesAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	return flow.EstimateTransactionFee(tx, *params), nil
+}
+
+const getFlowTokenBalanceScript = `
+import FungibleToken from 0x%s
+import FlowToken from 0x%s
+
+pub fun main(): UFix64 {
+  let vault = getAccount(0x%s)
+    .getCapability(/public/flowTokenBalance)!
+    .borrow<&FlowToken.Vault{FungibleToken.Balance}>()
+    ?? panic("Could not borrow Balance reference to the Vault")
+
+  return vault.balance
+}
+`
+
+// flowTokenContracts are the addresses of the FungibleToken and FlowToken contracts on each chain.
+var flowTokenContracts = map[flow.ChainID]struct {
+	fungibleToken flow.Address
+	flowToken     flow.Address
+}{
+	flow.Mainnet: {
+		fungibleToken: flow.HexToAddress("f233dcee88fe0abe"),
+		flowToken:     flow.HexToAddress("1654653399040a61"),
+	},
+	flow.Testnet: {
+		fungibleToken: flow.HexToAddress("9a0766d93b6608b7"),
+		flowToken:     flow.HexToAddress("7e60df042a9c0868"),
+	},
+	flow.Emulator: {
+		fungibleToken: flow.HexToAddress("ee82856bf20e2aa6"),
+		flowToken:     flow.HexToAddress("0ae53cb6e3f42a79"),
+	},
+	flow.Sandboxnet: {
+		fungibleToken: flow.HexToAddress("e20612a0776ca4bf"),
+		flowToken:     flow.HexToAddress("0661ab7d6696a460"),
+	},
+}
+
+// GetFlowTokenBalance gets the FLOW balance of the vault published at /public/flowTokenBalance
+// by the given account, using the FlowToken contract deployed on the given chain.
+//
+// The balance is returned as a UFix64 fixed-point number, scaled by flow.UFix64Factor.
+//
+// If the access node does not support script execution, the balance of the account
+// returned by GetAccount is used instead.
+//
+// This function returns an error if the chain ID is not known.
+func (c *Client) GetFlowTokenBalance(ctx context.Context, address flow.Address, chainID flow.ChainID) (uint64, error) {
+	contracts, ok := flowTokenContracts[chainID]
+	if !ok {
+		return 0, fmt.Errorf("client: unknown chain ID %s", chainID)
+	}
+
+	script := []byte(fmt.Sprintf(
+		getFlowTokenBalanceScript,
+		contracts.fungibleToken.Short(),
+		contracts.flowToken.Short(),
+		address.Short(),
+	))
+
+	value, err := c.ExecuteScriptAtLatestBlock(ctx, script)
+	if isUnsupportedByNode(err) {
+		account, err := c.GetAccount(ctx, address)
+		if err != nil {
+			return 0, fmt.Errorf("client: %w", err)
+		}
+
+		return account.Balance, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("client: %w", err)
+	}
+
+	balance, ok := value.(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("client: unexpected balance value %v", value)
+	}
+
+	return uint64(balance), nil
 }
 
 // EventRangeQuery defines a query for Flow events.
@@ -331,6 +935,27 @@ type BlockEvents struct {
 	Events  []flow.Event
 }
 
+// EventsByContract groups the events in this block by the contract that declared their type,
+// preserving the order of events within each group.
+//
+// Events are keyed by their type without the trailing event name; for example, events of
+// type "A.<address>.<contract>.<event>" are keyed by "A.<address>.<contract>" and built-in
+// events such as "flow.AccountCreated" are keyed by "flow".
+func (b BlockEvents) EventsByContract() map[string][]flow.Event {
+	groups := make(map[string][]flow.Event)
+
+	for _, event := range b.Events {
+		contract := event.Type
+		if i := strings.LastIndex(contract, "."); i >= 0 {
+			contract = contract[:i]
+		}
+
+		groups[contract] = append(groups[contract], event)
+	}
+
+	return groups
+}
+
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start and end block
 // heights (inclusive) with the given type.
 func (c *Client) GetEventsForHeightRange(ctx context.Context, query EventRangeQuery) ([]BlockEvents, error) {
@@ -343,7 +968,7 @@ func (c *Client) GetEventsForHeightRange(ctx context.Context, query EventRangeQu
 	res, err := c.rpcClient.GetEventsForHeightRange(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetEventsForHeightRange", err))
 	}
 
 	return getEventsResult(res)
@@ -363,12 +988,140 @@ func (c *Client) GetEventsForBlockIDs(
 	res, err := c.rpcClient.GetEventsForBlockIDs(ctx, req)
 	if err != nil {
 		// TODO: improve errors
-		return nil, fmt.Errorf("client: %w", err)
+		return nil, fmt.Errorf("client: %w", unsupportedByNode("GetEventsForBlockIDs", err))
 	}
 
 	return getEventsResult(res)
 }
 
+// GetEventsForAccount retrieves all events emitted by the contracts deployed to an account
+// for all sealed blocks between the start and end block heights (inclusive).
+//
+// The Access API cannot filter events by account, so the event types declared by the
+// contracts in the account code are queried individually. The results are merged into a
+// single list of blocks, ordered by height, with the events of each block ordered by
+// transaction index and event index.
+//
+// Only the event types declared by the current account code are queried.
+func (c *Client) GetEventsForAccount(
+	ctx context.Context,
+	address flow.Address,
+	start, end uint64,
+) ([]BlockEvents, error) {
+	account, err := c.GetAccount(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	eventTypes, err := accountEventTypes(address, account.Code)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	results := make([][]BlockEvents, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		blocks, err := c.GetEventsForHeightRange(ctx, EventRangeQuery{
+			Type:        eventType,
+			StartHeight: start,
+			EndHeight:   end,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, blocks)
+	}
+
+	return mergeBlockEvents(results), nil
+}
+
+// mergeBlockEvents merges the results of several event queries into a single list of blocks,
+// ordered by height, with the events of each block ordered by transaction index and event index.
+func mergeBlockEvents(results [][]BlockEvents) []BlockEvents {
+	blocks := make(map[uint64]*BlockEvents)
+
+	for _, result := range results {
+		for _, block := range result {
+			merged, ok := blocks[block.Height]
+			if !ok {
+				merged = &BlockEvents{
+					BlockID: block.BlockID,
+					Height:  block.Height,
+					Events:  make([]flow.Event, 0),
+				}
+				blocks[block.Height] = merged
+			}
+
+			merged.Events = append(merged.Events, block.Events...)
+		}
+	}
+
+	merged := make([]BlockEvents, 0, len(blocks))
+
+	for _, block := range blocks {
+		events := block.Events
+
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+
+			return events[i].EventIndex < events[j].EventIndex
+		})
+
+		merged = append(merged, *block)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Height < merged[j].Height
+	})
+
+	return merged
+}
+
+// accountEventTypes returns the qualified types of the events declared by the contracts
+// and contract interfaces in the given account code.
+func accountEventTypes(address flow.Address, code []byte) ([]string, error) {
+	if len(code) == 0 {
+		return nil, nil
+	}
+
+	program, _, err := parser.ParseProgram(string(code))
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := make([]string, 0)
+
+	addEvents := func(contractName string, declarations []*ast.CompositeDeclaration) {
+		for _, declaration := range declarations {
+			if declaration.CompositeKind != common.CompositeKindEvent {
+				continue
+			}
+
+			eventTypes = append(
+				eventTypes,
+				fmt.Sprintf("A.%s.%s.%s", address.Hex(), contractName, declaration.Identifier.Identifier),
+			)
+		}
+	}
+
+	for _, declaration := range program.CompositeDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract {
+			addEvents(declaration.Identifier.Identifier, declaration.CompositeDeclarations)
+		}
+	}
+
+	for _, declaration := range program.InterfaceDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract {
+			addEvents(declaration.Identifier.Identifier, declaration.CompositeDeclarations)
+		}
+	}
+
+	return eventTypes, nil
+}
+
 func getEventsResult(res *access.EventsResponse) ([]BlockEvents, error) {
 	resultMessages := res.GetResults()
 
@@ -21,12 +21,20 @@ package client_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/client"
@@ -392,6 +400,60 @@ func TestClient_SendTransaction(t *testing.T) {
 	})
 }
 
+func TestClient_SendTransactions(t *testing.T) {
+	transactions := test.TransactionGenerator()
+
+	rpc := &mocks.RPCClient{}
+
+	ctx := context.Background()
+
+	txs := make([]*flow.Transaction, 4)
+	for i := range txs {
+		// generated transactions are identical, so vary the gas limit
+		txs[i] = transactions.New().SetGasLimit(uint64(i + 1))
+	}
+
+	failing := txs[2]
+
+	for _, tx := range txs {
+		req := &access.SendTransactionRequest{
+			Transaction: convert.TransactionToMessage(*tx),
+		}
+
+		if tx == failing {
+			rpc.On("SendTransaction", ctx, req).
+				Return(nil, errors.New("rpc error")).
+				Once()
+			continue
+		}
+
+		rpc.On("SendTransaction", ctx, req).
+			Return(&access.SendTransactionResponse{Id: tx.ID().Bytes()}, nil).
+			Once()
+	}
+
+	c := client.NewFromRPCClient(rpc)
+
+	ids, errs := c.SendTransactions(ctx, txs)
+	require.Len(t, ids, len(txs))
+	require.Len(t, errs, len(txs))
+
+	for i, tx := range txs {
+		if tx == failing {
+			assert.Error(t, errs[i])
+			assert.Equal(t, flow.ZeroID, ids[i])
+			continue
+		}
+
+		assert.NoError(t, errs[i])
+		assert.Equal(t, tx.ID(), ids[i])
+	}
+
+	// each transaction is sent exactly once
+	rpc.AssertExpectations(t)
+	rpc.AssertNumberOfCalls(t, "SendTransaction", len(txs))
+}
+
 func TestClient_GetTransaction(t *testing.T) {
 	txs := test.TransactionGenerator()
 	ids := test.IdentifierGenerator()
@@ -484,6 +546,396 @@ func TestClient_GetTransactionResult(t *testing.T) {
 	})
 }
 
+func TestClient_CheckTransactionAuthorization(t *testing.T) {
+	addresses := test.AddressGenerator()
+	accountKeys := test.AccountKeyGenerator()
+
+	proposerAddress := addresses.New()
+	payerAddress := addresses.New()
+
+	proposerKeyA, proposerSignerA := accountKeys.NewWithSigner()
+	proposerKeyA.SetWeight(500)
+
+	proposerKeyB, proposerSignerB := accountKeys.NewWithSigner()
+	proposerKeyB.SetWeight(500)
+
+	payerKey, payerSigner := accountKeys.NewWithSigner()
+
+	splitPayerAddress := addresses.New()
+
+	splitPayerKeyA, splitPayerSignerA := accountKeys.NewWithSigner()
+	splitPayerKeyA.SetWeight(500)
+
+	splitPayerKeyB, splitPayerSignerB := accountKeys.NewWithSigner()
+	splitPayerKeyB.SetWeight(500)
+
+	accounts := map[flow.Address]flow.Account{
+		proposerAddress: {
+			Address: proposerAddress,
+			Keys:    []*flow.AccountKey{proposerKeyA, proposerKeyB},
+		},
+		payerAddress: {
+			Address: payerAddress,
+			Keys:    []*flow.AccountKey{payerKey},
+		},
+	}
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.ScriptHelloWorld).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(proposerAddress, proposerKeyA.ID, proposerKeyA.SequenceNumber).
+			AddAuthorizer(proposerAddress).
+			SetPayer(payerAddress)
+	}
+
+	newRPCClient := func(t *testing.T, ctx context.Context, extra ...flow.Account) *mocks.RPCClient {
+		rpc := &mocks.RPCClient{}
+
+		registered := make([]flow.Account, 0, len(accounts)+len(extra))
+		for _, account := range accounts {
+			registered = append(registered, account)
+		}
+		registered = append(registered, extra...)
+
+		for _, account := range registered {
+			msg, err := convert.AccountToMessage(account)
+			require.NoError(t, err)
+
+			address := account.Address
+			rpc.On(
+				"GetAccount",
+				ctx,
+				mock.MatchedBy(func(req *access.GetAccountRequest) bool {
+					return flow.BytesToAddress(req.GetAddress()) == address
+				}),
+			).Return(&access.GetAccountResponse{Account: msg}, nil)
+		}
+
+		return rpc
+	}
+
+	t.Run("Fully authorized", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := newRPCClient(t, ctx)
+
+		tx := newTransaction()
+
+		err := tx.SignPayload(proposerAddress, proposerKeyA.ID, proposerSignerA)
+		require.NoError(t, err)
+
+		err = tx.SignPayload(proposerAddress, proposerKeyB.ID, proposerSignerB)
+		require.NoError(t, err)
+
+		err = tx.SignEnvelope(payerAddress, payerKey.ID, payerSigner)
+		require.NoError(t, err)
+
+		c := client.NewFromRPCClient(rpc)
+
+		complete, reasons, err := c.CheckTransactionAuthorization(ctx, tx)
+		require.NoError(t, err)
+
+		assert.True(t, complete)
+		assert.Empty(t, reasons)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Under-signed", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := newRPCClient(t, ctx)
+
+		tx := newTransaction()
+
+		err := tx.SignPayload(proposerAddress, proposerKeyA.ID, proposerSignerA)
+		require.NoError(t, err)
+
+		c := client.NewFromRPCClient(rpc)
+
+		complete, reasons, err := c.CheckTransactionAuthorization(ctx, tx)
+		require.NoError(t, err)
+
+		assert.False(t, complete)
+		assert.Equal(t, []string{
+			fmt.Sprintf("payer %s has not signed the envelope", payerAddress),
+			fmt.Sprintf("authorizer %s has insufficient signature weight 500, requires 1000", proposerAddress),
+		}, reasons)
+	})
+
+	t.Run("Missing proposal key signature", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := newRPCClient(t, ctx)
+
+		tx := newTransaction()
+
+		err := tx.SignEnvelope(payerAddress, payerKey.ID, payerSigner)
+		require.NoError(t, err)
+
+		c := client.NewFromRPCClient(rpc)
+
+		complete, reasons, err := c.CheckTransactionAuthorization(ctx, tx)
+		require.NoError(t, err)
+
+		assert.False(t, complete)
+		assert.Equal(t, []string{
+			fmt.Sprintf("proposal key %d on account %s has not signed", proposerKeyA.ID, proposerAddress),
+			fmt.Sprintf("authorizer %s has insufficient signature weight 0, requires 1000", proposerAddress),
+		}, reasons)
+	})
+
+	t.Run("Payer weight split between payload and envelope", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := newRPCClient(t, ctx, flow.Account{
+			Address: splitPayerAddress,
+			Keys:    []*flow.AccountKey{splitPayerKeyA, splitPayerKeyB},
+		})
+
+		tx := newTransaction().SetPayer(splitPayerAddress)
+
+		err := tx.SignPayload(proposerAddress, proposerKeyA.ID, proposerSignerA)
+		require.NoError(t, err)
+
+		err = tx.SignPayload(proposerAddress, proposerKeyB.ID, proposerSignerB)
+		require.NoError(t, err)
+
+		// the payer only signs the envelope, so its payload signature does not count
+		sig, err := splitPayerSignerA.Sign(tx.PayloadMessage())
+		require.NoError(t, err)
+		tx.AddPayloadSignature(splitPayerAddress, splitPayerKeyA.ID, sig)
+
+		err = tx.SignEnvelope(splitPayerAddress, splitPayerKeyB.ID, splitPayerSignerB)
+		require.NoError(t, err)
+
+		c := client.NewFromRPCClient(rpc)
+
+		complete, reasons, err := c.CheckTransactionAuthorization(ctx, tx)
+		require.NoError(t, err)
+
+		assert.False(t, complete)
+		assert.Equal(t, []string{
+			fmt.Sprintf("payer %s has insufficient signature weight 500, requires 1000", splitPayerAddress),
+		}, reasons)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		ctx := context.Background()
+		rpc := newRPCClient(t, ctx)
+
+		tx := newTransaction()
+
+		err := tx.SignPayload(proposerAddress, proposerKeyA.ID, proposerSignerA)
+		require.NoError(t, err)
+
+		// a signature of another message is invalid for the payload
+		sig, err := proposerSignerB.Sign([]byte("another message"))
+		require.NoError(t, err)
+		tx.AddPayloadSignature(proposerAddress, proposerKeyB.ID, sig)
+
+		err = tx.SignEnvelope(payerAddress, payerKey.ID, payerSigner)
+		require.NoError(t, err)
+
+		c := client.NewFromRPCClient(rpc)
+
+		complete, reasons, err := c.CheckTransactionAuthorization(ctx, tx)
+		require.NoError(t, err)
+
+		// the remaining checks are still made, without any signature weight
+		assert.False(t, complete)
+		require.Len(t, reasons, 3)
+		assert.Contains(t, reasons[0], fmt.Sprintf("key %d on account %s", proposerKeyB.ID, proposerAddress))
+		assert.Equal(t, []string{
+			fmt.Sprintf("payer %s has insufficient signature weight 0, requires 1000", payerAddress),
+			fmt.Sprintf("authorizer %s has insufficient signature weight 0, requires 1000", proposerAddress),
+		}, reasons[1:])
+	})
+
+	t.Run("Account error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("GetAccount", ctx, mock.Anything).Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, _, err := c.CheckTransactionAuthorization(ctx, newTransaction())
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_GetFlowTokenBalance(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		address := addresses.New()
+
+		// 12.5 FLOW
+		payload, err := jsoncdc.Encode(cadence.NewUFix64(1250000000))
+		require.NoError(t, err)
+
+		response := &access.ExecuteScriptResponse{
+			Value: payload,
+		}
+
+		rpc.On(
+			"ExecuteScriptAtLatestBlock",
+			ctx,
+			mock.MatchedBy(func(req *access.ExecuteScriptAtLatestBlockRequest) bool {
+				script := string(req.GetScript())
+				return strings.Contains(script, "import FlowToken from 0x1654653399040a61") &&
+					strings.Contains(script, "import FungibleToken from 0xf233dcee88fe0abe") &&
+					strings.Contains(script, "getAccount(0x"+address.Short()+")")
+			}),
+		).Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		balance, err := c.GetFlowTokenBalance(ctx, address, flow.Mainnet)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1250000000), balance)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Unexpected value", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		payload, err := jsoncdc.Encode(cadence.NewInt(100))
+		require.NoError(t, err)
+
+		response := &access.ExecuteScriptResponse{
+			Value: payload,
+		}
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, err = c.GetFlowTokenBalance(ctx, addresses.New(), flow.Emulator)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown chain", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, err := c.GetFlowTokenBalance(context.Background(), addresses.New(), "flow-unknown")
+		assert.Error(t, err)
+
+		rpc.AssertNotCalled(t, "ExecuteScriptAtLatestBlock", mock.Anything, mock.Anything)
+	})
+}
+
+func TestClient_GetTransactionEvents(t *testing.T) {
+	results := test.TransactionResultGenerator()
+	ids := test.IdentifierGenerator()
+
+	t.Run("Sealed", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		txID := ids.New()
+		result := results.New()
+		require.NotEmpty(t, result.Events)
+
+		response, err := convert.TransactionResultToMessage(result)
+		require.NoError(t, err)
+
+		rpc.On("GetTransactionResult", ctx, &access.GetTransactionRequest{Id: txID.Bytes()}).
+			Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		events, err := c.GetTransactionEvents(ctx, txID)
+		require.NoError(t, err)
+
+		assert.Equal(t, result.Events, events)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Not sealed", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		result := results.New()
+		result.Status = flow.TransactionStatusExecuted
+
+		response, err := convert.TransactionResultToMessage(result)
+		require.NoError(t, err)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		events, err := c.GetTransactionEvents(ctx, ids.New())
+		require.NoError(t, err)
+
+		assert.NotNil(t, events)
+		assert.Empty(t, events)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		events, err := c.GetTransactionEvents(ctx, ids.New())
+		assert.Error(t, err)
+		assert.Nil(t, events)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestBlockEvents_EventsByContract(t *testing.T) {
+	events := test.EventGenerator()
+
+	newEvent := func(eventType string) flow.Event {
+		event := events.New()
+		event.Type = eventType
+		return event
+	}
+
+	fooA := newEvent("A.0000000000000000000000000000000000000001.Foo.A")
+	barA := newEvent("A.0000000000000000000000000000000000000001.Bar.A")
+	fooB := newEvent("A.0000000000000000000000000000000000000001.Foo.B")
+	bazA := newEvent("A.0000000000000000000000000000000000000002.Baz.A")
+	barB := newEvent("A.0000000000000000000000000000000000000001.Bar.B")
+	accountCreated := newEvent(flow.EventAccountCreated)
+
+	block := client.BlockEvents{
+		Height: 42,
+		Events: []flow.Event{fooA, barA, fooB, bazA, barB, accountCreated},
+	}
+
+	groups := block.EventsByContract()
+
+	assert.Equal(t, map[string][]flow.Event{
+		"A.0000000000000000000000000000000000000001.Foo": {fooA, fooB},
+		"A.0000000000000000000000000000000000000001.Bar": {barA, barB},
+		"A.0000000000000000000000000000000000000002.Baz": {bazA},
+		"flow": {accountCreated},
+	}, groups)
+}
+
 func TestClient_GetEventsForHeightRange(t *testing.T) {
 	ids := test.IdentifierGenerator()
 	events := test.EventGenerator()
@@ -700,3 +1152,654 @@ func TestClient_GetEventsForBlockIDs(t *testing.T) {
 		rpc.AssertExpectations(t)
 	})
 }
+
+func TestClient_ExecuteScriptAtLatestBlock(t *testing.T) {
+	script := []byte("pub fun main(): Int { return 42 }")
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		payload, err := jsoncdc.Encode(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		response := &access.ExecuteScriptResponse{
+			Value: payload,
+		}
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, &access.ExecuteScriptAtLatestBlockRequest{Script: script}).
+			Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptAtLatestBlock(ctx, script)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42), value)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptAtLatestBlock(ctx, script)
+		assert.Error(t, err)
+		assert.Nil(t, value)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// the node does not respond until the test has finished
+		unblock := make(chan time.Time)
+		defer close(unblock)
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			WaitUntil(unblock).
+			Return(&access.ExecuteScriptResponse{}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		start := time.Now()
+
+		value, err := c.ExecuteScriptAtLatestBlock(ctx, script)
+		require.Error(t, err)
+		assert.Nil(t, value)
+
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+}
+
+func TestClient_ExecuteScriptAtBlockID(t *testing.T) {
+	script := []byte("pub fun main(): Int { return 42 }")
+	blockID := test.IdentifierGenerator().New()
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		payload, err := jsoncdc.Encode(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtBlockID", ctx, &access.ExecuteScriptAtBlockIDRequest{
+			BlockId: blockID.Bytes(),
+			Script:  script,
+		}).Return(&access.ExecuteScriptResponse{Value: payload}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptAtBlockID(ctx, blockID, script)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42), value)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtBlockID", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptAtBlockID(ctx, blockID, script)
+		assert.Error(t, err)
+		assert.Nil(t, value)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_ExecuteScriptAtBlockHeight(t *testing.T) {
+	script := []byte("pub fun main(): Int { return 42 }")
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		payload, err := jsoncdc.Encode(cadence.NewInt(42))
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtBlockHeight", ctx, &access.ExecuteScriptAtBlockHeightRequest{
+			BlockHeight: 1234,
+			Script:      script,
+		}).Return(&access.ExecuteScriptResponse{Value: payload}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptAtBlockHeight(ctx, 1234, script)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42), value)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtBlockHeight", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptAtBlockHeight(ctx, 1234, script)
+		assert.Error(t, err)
+		assert.Nil(t, value)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_GetEventsForAccount(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	events := test.EventGenerator()
+
+	address := test.AddressGenerator().New()
+
+	code := []byte(`
+		pub contract Foo {
+			pub event FooEvent(a: Int)
+		}
+
+		pub contract Bar {
+			pub event BarEvent(b: Int)
+
+			pub resource Baz {}
+		}
+	`)
+
+	fooType := fmt.Sprintf("A.%s.Foo.FooEvent", address.Hex())
+	barType := fmt.Sprintf("A.%s.Bar.BarEvent", address.Hex())
+
+	newEvent := func(eventType string, txIndex int) (flow.Event, *entities.Event) {
+		event := events.New()
+		event.Type = eventType
+		event.TransactionIndex = txIndex
+
+		msg, err := convert.EventToMessage(event)
+		require.NoError(t, err)
+
+		return event, msg
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		accountMsg, err := convert.AccountToMessage(flow.Account{
+			Address: address,
+			Code:    code,
+		})
+		require.NoError(t, err)
+
+		rpc.On("GetAccount", ctx, &access.GetAccountRequest{Address: address.Bytes()}).
+			Return(&access.GetAccountResponse{Account: accountMsg}, nil)
+
+		blockA := ids.New()
+		blockB := ids.New()
+
+		fooEventA, fooEventAMsg := newEvent(fooType, 1)
+		fooEventB, fooEventBMsg := newEvent(fooType, 0)
+		barEventA, barEventAMsg := newEvent(barType, 0)
+
+		rpc.On("GetEventsForHeightRange", ctx, &access.GetEventsForHeightRangeRequest{
+			Type:        fooType,
+			StartHeight: 1,
+			EndHeight:   10,
+		}).Return(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{
+				{BlockId: blockA.Bytes(), BlockHeight: 1, Events: []*entities.Event{fooEventAMsg}},
+				{BlockId: blockB.Bytes(), BlockHeight: 2, Events: []*entities.Event{fooEventBMsg}},
+			},
+		}, nil)
+
+		rpc.On("GetEventsForHeightRange", ctx, &access.GetEventsForHeightRangeRequest{
+			Type:        barType,
+			StartHeight: 1,
+			EndHeight:   10,
+		}).Return(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{
+				{BlockId: blockA.Bytes(), BlockHeight: 1, Events: []*entities.Event{barEventAMsg}},
+			},
+		}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		blocks, err := c.GetEventsForAccount(ctx, address, 1, 10)
+		require.NoError(t, err)
+
+		require.Len(t, blocks, 2)
+
+		assert.Equal(t, blockA, blocks[0].BlockID)
+		assert.Equal(t, uint64(1), blocks[0].Height)
+		assert.Equal(t, []flow.Event{barEventA, fooEventA}, blocks[0].Events)
+
+		assert.Equal(t, blockB, blocks[1].BlockID)
+		assert.Equal(t, uint64(2), blocks[1].Height)
+		assert.Equal(t, []flow.Event{fooEventB}, blocks[1].Events)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("No code", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		accountMsg, err := convert.AccountToMessage(flow.Account{Address: address})
+		require.NoError(t, err)
+
+		rpc.On("GetAccount", ctx, mock.Anything).
+			Return(&access.GetAccountResponse{Account: accountMsg}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		blocks, err := c.GetEventsForAccount(ctx, address, 1, 10)
+		require.NoError(t, err)
+
+		assert.Empty(t, blocks)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("GetAccount", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		blocks, err := c.GetEventsForAccount(ctx, address, 1, 10)
+		assert.Error(t, err)
+		assert.Nil(t, blocks)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_ExecuteScriptExpectType(t *testing.T) {
+	script := []byte("pub fun main(): [Int] { return [1, 2] }")
+
+	newResponse := func(t *testing.T, value cadence.Value) *access.ExecuteScriptResponse {
+		payload, err := jsoncdc.Encode(value)
+		require.NoError(t, err)
+
+		return &access.ExecuteScriptResponse{Value: payload}
+	}
+
+	result := cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.NewInt(2)})
+
+	t.Run("Matching type", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(newResponse(t, result), nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptExpectType(
+			ctx,
+			script,
+			nil,
+			cadence.VariableSizedArrayType{ElementType: cadence.IntType{}},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, result.Values, value.(cadence.Array).Values)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Mismatching type", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(newResponse(t, result), nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		value, err := c.ExecuteScriptExpectType(
+			ctx,
+			script,
+			nil,
+			cadence.VariableSizedArrayType{ElementType: cadence.StringType{}},
+		)
+		assert.Error(t, err)
+		assert.Nil(t, value)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Arguments", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, err := c.ExecuteScriptExpectType(
+			context.Background(),
+			script,
+			[]cadence.Value{cadence.NewInt(1)},
+			cadence.IntType{},
+		)
+		assert.Error(t, err)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_GetFeeParameters(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		value := cadence.NewArray([]cadence.Value{
+			cadence.NewUFix64(100000000),
+			cadence.NewUFix64(100),
+			cadence.NewUFix64(4000),
+		})
+
+		payload, err := jsoncdc.Encode(value)
+		require.NoError(t, err)
+
+		response := &access.ExecuteScriptResponse{
+			Value: payload,
+		}
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		params, err := c.GetFeeParameters(ctx, addresses.New())
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.FeeParameters{
+			SurgeFactor:           100000000,
+			InclusionEffortFactor: 100,
+			ExecutionEffortFactor: 4000,
+		}, *params)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Script reads FlowFees fields", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		feesAddress := addresses.New()
+
+		var script string
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				script = string(args.Get(1).(*access.ExecuteScriptAtLatestBlockRequest).GetScript())
+			}).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, _ = c.GetFeeParameters(ctx, feesAddress)
+
+		assert.Contains(t, script, "import FlowFees from 0x"+feesAddress.Short())
+		assert.Contains(t, script, "params.surgeFactor")
+		assert.Contains(t, script, "params.inclusionEffortFactor")
+		assert.Contains(t, script, "params.executionEffortFactor")
+	})
+
+	t.Run("Unexpected value", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		payload, err := jsoncdc.Encode(cadence.NewUFix64(100))
+		require.NoError(t, err)
+
+		response := &access.ExecuteScriptResponse{
+			Value: payload,
+		}
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).Return(response, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		params, err := c.GetFeeParameters(ctx, addresses.New())
+		assert.Error(t, err)
+		assert.Nil(t, params)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		params, err := c.GetFeeParameters(ctx, addresses.New())
+		assert.Error(t, err)
+		assert.Nil(t, params)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_EstimateTransactionFee(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	t.Run("Success", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		value := cadence.NewArray([]cadence.Value{
+			cadence.NewUFix64(250000000),
+			cadence.NewUFix64(100),
+			cadence.NewUFix64(4000),
+		})
+
+		payload, err := jsoncdc.Encode(value)
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(&access.ExecuteScriptResponse{Value: payload}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		tx := test.TransactionGenerator().New().SetGasLimit(10)
+
+		fee, err := c.EstimateTransactionFee(ctx, tx, addresses.New())
+		require.NoError(t, err)
+
+		// 2.5 * (1.0 * 0.000001 + 10.0 * 0.00004)
+		assert.Equal(t, uint64(100250), fee)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, err := c.EstimateTransactionFee(ctx, test.TransactionGenerator().New(), addresses.New())
+		assert.Error(t, err)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_UnsupportedByNode(t *testing.T) {
+	unimplemented := status.Error(codes.Unimplemented, "unknown method")
+
+	t.Run("Typed error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("GetCollectionByID", ctx, mock.Anything).Return(nil, unimplemented)
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, err := c.GetCollection(ctx, test.IdentifierGenerator().New())
+		require.Error(t, err)
+
+		var unsupported client.ErrUnsupportedByNode
+		require.True(t, errors.As(err, &unsupported))
+		assert.Equal(t, "GetCollectionByID", unsupported.Method)
+	})
+
+	t.Run("Other errors", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("GetCollectionByID", ctx, mock.Anything).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		_, err := c.GetCollection(ctx, test.IdentifierGenerator().New())
+		require.Error(t, err)
+
+		var unsupported client.ErrUnsupportedByNode
+		assert.False(t, errors.As(err, &unsupported))
+	})
+
+	t.Run("Block header fallback", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		block := test.BlockGenerator().New()
+
+		msg := convert.BlockToMessage(*block)
+
+		rpc.On("GetBlockHeaderByHeight", ctx, mock.Anything).Return(nil, unimplemented)
+		rpc.On("GetBlockByHeight", ctx, &access.GetBlockByHeightRequest{Height: block.Height}).
+			Return(&access.BlockResponse{Block: msg}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		header, err := c.GetBlockHeaderByHeight(ctx, block.Height)
+		require.NoError(t, err)
+
+		assert.Equal(t, block.BlockHeader, *header)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Balance fallback", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		account := test.AccountGenerator().New()
+
+		msg, err := convert.AccountToMessage(*account)
+		require.NoError(t, err)
+
+		rpc.On("ExecuteScriptAtLatestBlock", ctx, mock.Anything).Return(nil, unimplemented)
+		rpc.On("GetAccount", ctx, &access.GetAccountRequest{Address: account.Address.Bytes()}).
+			Return(&access.GetAccountResponse{Account: msg}, nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		balance, err := c.GetFlowTokenBalance(ctx, account.Address, flow.Mainnet)
+		require.NoError(t, err)
+
+		assert.Equal(t, account.Balance, balance)
+
+		rpc.AssertExpectations(t)
+	})
+}
+
+func TestClient_TransactionValidityWindow(t *testing.T) {
+	newHeader := func(height uint64) *access.BlockHeaderResponse {
+		header := test.BlockGenerator().New().BlockHeader
+		header.Height = height
+
+		return &access.BlockHeaderResponse{Block: convert.BlockHeaderToMessage(header)}
+	}
+
+	tests := []struct {
+		name            string
+		referenceHeight uint64
+		latestHeight    uint64
+		blocksRemaining uint64
+	}{
+		{"Fresh", 1000, 1000, 600},
+		{"Near expiry", 1000, 1590, 10},
+		{"Expired", 1000, 1600, 0},
+		{"Long expired", 1000, 5000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc := &mocks.RPCClient{}
+
+			ctx := context.Background()
+
+			tx := test.TransactionGenerator().New()
+
+			rpc.On("GetBlockHeaderByID", ctx, &access.GetBlockHeaderByIDRequest{Id: tx.ReferenceBlockID.Bytes()}).
+				Return(newHeader(tt.referenceHeight), nil)
+			rpc.On("GetLatestBlockHeader", ctx, &access.GetLatestBlockHeaderRequest{IsSealed: true}).
+				Return(newHeader(tt.latestHeight), nil)
+
+			c := client.NewFromRPCClient(rpc)
+
+			blocksRemaining, approxTime, err := c.TransactionValidityWindow(ctx, tx)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.blocksRemaining, blocksRemaining)
+			assert.Equal(t, time.Duration(tt.blocksRemaining)*time.Second, approxTime)
+
+			rpc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// A connectionStateWatcher reports the state of a gRPC connection.
+//
+// It is implemented by *grpc.ClientConn.
+type connectionStateWatcher interface {
+	GetState() connectivity.State
+	WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool
+}
+
+// OnConnectionStateChange registers a callback that is invoked with the new state of
+// the underlying gRPC connection each time the state changes, until the client is closed.
+//
+// The callback is invoked sequentially from a background goroutine, which stops when
+// Close is called. Transitions that happen while the callback is running are reported
+// as a single change to the latest state.
+//
+// The callback is never invoked for a client created with NewFromRPCClient.
+func (c *Client) OnConnectionStateChange(callback func(state connectivity.State)) {
+	if c.conn == nil {
+		return
+	}
+
+	go func() {
+		state := c.conn.GetState()
+
+		for c.conn.WaitForStateChange(c.ctx, state) {
+			state = c.conn.GetState()
+			callback(state)
+		}
+	}()
+}
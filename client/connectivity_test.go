@@ -0,0 +1,108 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+)
+
+// fakeConnection is a connectionStateWatcher whose state transitions are driven by the test.
+type fakeConnection struct {
+	mu          sync.Mutex
+	state       connectivity.State
+	transitions chan connectivity.State
+	stopped     chan struct{}
+}
+
+func newFakeConnection() *fakeConnection {
+	return &fakeConnection{
+		state:       connectivity.Idle,
+		transitions: make(chan connectivity.State),
+		stopped:     make(chan struct{}),
+	}
+}
+
+func (f *fakeConnection) GetState() connectivity.State {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.state
+}
+
+func (f *fakeConnection) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	select {
+	case state := <-f.transitions:
+		f.mu.Lock()
+		f.state = state
+		f.mu.Unlock()
+
+		return true
+	case <-ctx.Done():
+		close(f.stopped)
+		return false
+	}
+}
+
+func TestClient_OnConnectionStateChange(t *testing.T) {
+	conn := newFakeConnection()
+
+	c := newClient(nil, conn, func() error { return nil })
+
+	states := make(chan connectivity.State, 3)
+
+	c.OnConnectionStateChange(func(state connectivity.State) {
+		states <- state
+	})
+
+	expected := []connectivity.State{
+		connectivity.Connecting,
+		connectivity.Ready,
+		connectivity.TransientFailure,
+	}
+
+	for _, state := range expected {
+		conn.transitions <- state
+		assert.Equal(t, state, <-states)
+	}
+
+	require.NoError(t, c.Close())
+
+	select {
+	case <-conn.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop after Close")
+	}
+}
+
+func TestClient_OnConnectionStateChange_FromRPCClient(t *testing.T) {
+	c := NewFromRPCClient(nil)
+
+	c.OnConnectionStateChange(func(state connectivity.State) {
+		t.Errorf("unexpected state change %s", state)
+	})
+
+	assert.NoError(t, c.Close())
+}
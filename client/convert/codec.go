@@ -0,0 +1,56 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package convert
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ProtobufTransactionCodec is a flow.TransactionCodec that encodes transactions
+// as entities.Transaction protobuf messages, the format used by the Access API.
+type ProtobufTransactionCodec struct{}
+
+var _ flow.TransactionCodec = ProtobufTransactionCodec{}
+
+// Encode returns the transaction encoded as an entities.Transaction protobuf message.
+func (ProtobufTransactionCodec) Encode(tx *flow.Transaction) ([]byte, error) {
+	return proto.Marshal(TransactionToMessage(*tx))
+}
+
+// Decode decodes a transaction from an entities.Transaction protobuf message.
+//
+// This function returns an error if the message is malformed or contains an invalid transaction.
+func (ProtobufTransactionCodec) Decode(b []byte) (*flow.Transaction, error) {
+	var m entities.Transaction
+
+	err := proto.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := MessageToTransaction(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
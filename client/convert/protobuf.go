@@ -358,6 +358,7 @@ func MessageToAccountKey(m *entities.AccountKey) (*flow.AccountKey, error) {
 	}
 
 	return &flow.AccountKey{
+		ID:             int(m.GetIndex()),
 		PublicKey:      publicKey,
 		SigAlgo:        sigAlgo,
 		HashAlgo:       hashAlgo,
@@ -370,6 +371,7 @@ func AccountKeyToMessage(a *flow.AccountKey) (*entities.AccountKey, error) {
 	publicKey := a.PublicKey.Encode()
 
 	return &entities.AccountKey{
+		Index:          uint32(a.ID),
 		PublicKey:      publicKey,
 		SignAlgo:       uint32(a.SigAlgo),
 		HashAlgo:       uint32(a.HashAlgo),
@@ -379,14 +381,18 @@ func AccountKeyToMessage(a *flow.AccountKey) (*entities.AccountKey, error) {
 }
 
 func MessageToEvent(m *entities.Event) (flow.Event, error) {
+	if m == nil {
+		return flow.Event{}, ErrEmptyMessage
+	}
+
 	value, err := jsoncdc.Decode(m.GetPayload())
 	if err != nil {
-		return flow.Event{}, nil
+		return flow.Event{}, fmt.Errorf("convert: %w", err)
 	}
 
 	eventValue, isEvent := value.(cadence.Event)
 	if !isEvent {
-		return flow.Event{}, fmt.Errorf("convert: expected Event value, got %s", eventValue.Type().ID())
+		return flow.Event{}, fmt.Errorf("convert: expected Event value, got %s", value.Type().ID())
 	}
 
 	return flow.Event{
@@ -21,6 +21,7 @@ package convert_test
 import (
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -64,6 +65,46 @@ func TestConvert_Transaction(t *testing.T) {
 func TestConvert_Event(t *testing.T) {
 	eventA := test.EventGenerator().New()
 
+	eventA.TransactionIndex = 3
+	eventA.EventIndex = 7
+
+	msg, err := convert.EventToMessage(eventA)
+	require.NoError(t, err)
+
+	assert.Equal(t, eventA.TransactionID.Bytes(), msg.GetTransactionId())
+	assert.EqualValues(t, 3, msg.GetTransactionIndex())
+	assert.EqualValues(t, 7, msg.GetEventIndex())
+
+	eventB, err := convert.MessageToEvent(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, eventA.TransactionID, eventB.TransactionID)
+	assert.Equal(t, eventA.TransactionIndex, eventB.TransactionIndex)
+	assert.Equal(t, eventA.EventIndex, eventB.EventIndex)
+	assert.Equal(t, eventA, eventB)
+}
+
+func TestConvert_EventWithType(t *testing.T) {
+	transferType := "A.0000000000000000000000000000000000000001.FlowToken.TokensDeposited"
+
+	events := test.EventGenerator().WithType(transferType, []cadence.Field{
+		{
+			Identifier: "amount",
+			Type:       cadence.UFix64Type{},
+		},
+		{
+			Identifier: "to",
+			Type:       cadence.AddressType{},
+		},
+	})
+
+	eventA := events.New()
+
+	assert.Equal(t, transferType, eventA.Type)
+	assert.Equal(t, transferType, eventA.Value.EventType.TypeID)
+	assert.Equal(t, "TokensDeposited", eventA.Value.EventType.Identifier)
+	require.Len(t, eventA.Value.Fields, 2)
+
 	msg, err := convert.EventToMessage(eventA)
 	require.NoError(t, err)
 
@@ -71,4 +112,22 @@ func TestConvert_Event(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, eventA, eventB)
+
+	amount, ok := eventB.Value.Fields[0].(cadence.UFix64)
+	require.True(t, ok)
+	assert.Equal(t, cadence.NewUFix64(100000000), amount)
+
+	assert.IsType(t, cadence.Address{}, eventB.Value.Fields[1])
+}
+
+func TestConvert_Account(t *testing.T) {
+	accountA := test.AccountGenerator().New()
+
+	msg, err := convert.AccountToMessage(*accountA)
+	require.NoError(t, err)
+
+	accountB, err := convert.MessageToAccount(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, *accountA, accountB)
 }
@@ -0,0 +1,460 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+)
+
+// dialTimeoutOption is a dial option that bounds the time taken to establish
+// the initial connection in New.
+//
+// The embedded option makes the dial blocking, and the timeout is applied by New.
+type dialTimeoutOption struct {
+	grpc.DialOption
+	timeout time.Duration
+}
+
+// WithDialTimeout returns a dial option that makes New wait for the connection to the
+// access node to be established, failing if it is not established within the given duration.
+//
+// By default, New does not wait for the connection to be established, and connection
+// errors are instead returned by the first request.
+func WithDialTimeout(d time.Duration) grpc.DialOption {
+	return dialTimeoutOption{
+		DialOption: grpc.WithBlock(),
+		timeout:    d,
+	}
+}
+
+// dialTimeout returns the timeout set by the last WithDialTimeout option in opts, if any.
+func dialTimeout(opts []grpc.DialOption) (time.Duration, bool) {
+	var (
+		timeout time.Duration
+		ok      bool
+	)
+
+	for _, opt := range opts {
+		if o, isTimeout := opt.(dialTimeoutOption); isTimeout {
+			timeout = o.timeout
+			ok = true
+		}
+	}
+
+	return timeout, ok
+}
+
+// ErrDuplicateSubmission indicates that a transaction was not sent because it was already
+// submitted within the TTL of the deduplication cache.
+var ErrDuplicateSubmission = errors.New("duplicate transaction submission")
+
+// A DuplicateSubmissionError is returned when a transaction is not sent because it was
+// already submitted within the TTL of the deduplication cache.
+//
+// DuplicateSubmissionError matches ErrDuplicateSubmission when used with errors.Is.
+type DuplicateSubmissionError struct {
+	// TransactionID is the ID of the previously submitted transaction.
+	TransactionID flow.Identifier
+}
+
+func (e DuplicateSubmissionError) Error() string {
+	return fmt.Sprintf("%s: transaction %s", ErrDuplicateSubmission, e.TransactionID)
+}
+
+func (e DuplicateSubmissionError) Is(target error) bool {
+	return target == ErrDuplicateSubmission
+}
+
+// maxDedupCacheSize is the maximum number of transaction IDs held by a deduplication cache.
+const maxDedupCacheSize = 10000
+
+// WithDedupCache returns a dial option that prevents the same transaction from being
+// submitted more than once within the given TTL.
+//
+// A transaction is recorded when it is sent, and forgotten if the node rejects it so that
+// it can be resubmitted. A transaction sent again while recorded is not sent, and a
+// DuplicateSubmissionError is returned instead. The cache holds at most 10000 transactions;
+// the oldest transactions are forgotten first once it is full.
+func WithDedupCache(ttl time.Duration) grpc.DialOption {
+	cache := newDedupCache(ttl, maxDedupCacheSize)
+	return grpc.WithChainUnaryInterceptor(cache.intercept)
+}
+
+type dedupCacheEntry struct {
+	id        flow.Identifier
+	expiresAt time.Time
+}
+
+// dedupCache is a concurrency-safe, bounded set of recently submitted transaction IDs.
+type dedupCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	// elements holds the entry of each recorded ID
+	elements map[flow.Identifier]*list.Element
+	// entries are held in the order they were added, which is also the order of expiry
+	entries *list.List
+}
+
+func newDedupCache(ttl time.Duration, maxSize int) *dedupCache {
+	return &dedupCache{
+		ttl:      ttl,
+		maxSize:  maxSize,
+		elements: make(map[flow.Identifier]*list.Element),
+		entries:  list.New(),
+	}
+}
+
+// reserve records a transaction ID, returning false if it is already recorded.
+func (c *dedupCache) reserve(id flow.Identifier, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evict(now)
+
+	if _, ok := c.elements[id]; ok {
+		return false
+	}
+
+	c.elements[id] = c.entries.PushBack(dedupCacheEntry{id: id, expiresAt: now.Add(c.ttl)})
+
+	return true
+}
+
+// release forgets a transaction ID.
+func (c *dedupCache) release(id flow.Identifier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.elements[id]
+	if !ok {
+		return
+	}
+
+	c.entries.Remove(element)
+	delete(c.elements, id)
+}
+
+// evict removes expired entries, and the oldest entries if the cache is full.
+func (c *dedupCache) evict(now time.Time) {
+	for element := c.entries.Front(); element != nil; element = c.entries.Front() {
+		entry := element.Value.(dedupCacheEntry)
+
+		if now.Before(entry.expiresAt) && c.entries.Len() < c.maxSize {
+			return
+		}
+
+		c.entries.Remove(element)
+		delete(c.elements, entry.id)
+	}
+}
+
+func (c *dedupCache) intercept(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	sendReq, ok := req.(*access.SendTransactionRequest)
+	if !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	tx, err := convert.MessageToTransaction(sendReq.GetTransaction())
+	if err != nil {
+		// leave malformed transactions to be rejected by the node
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	id := tx.ID()
+
+	if !c.reserve(id, time.Now()) {
+		return DuplicateSubmissionError{TransactionID: id}
+	}
+
+	err = invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		c.release(id)
+	}
+
+	return err
+}
+
+// maxRetryDelay is the maximum delay between two attempts of a retried request.
+const maxRetryDelay = 30 * time.Second
+
+// defaultRetryableCodes are the gRPC status codes of transient errors that are retried by default.
+var defaultRetryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.DeadlineExceeded:  true,
+	codes.ResourceExhausted: true,
+}
+
+// hasRetryableCode returns a retry predicate that accepts errors with a gRPC status code in the given set.
+func hasRetryableCode(retryable map[codes.Code]bool) func(error) bool {
+	return func(err error) bool {
+		return retryable[status.Code(err)]
+	}
+}
+
+// A RetryOption configures the retries of a client created with WithRetry.
+type RetryOption func(*retryPolicy)
+
+// RetrySendTransaction makes WithRetry also retry transaction submissions.
+//
+// Sending a transaction is not idempotent, so it is not retried by default: a request
+// that timed out may still have been accepted by the node.
+func RetrySendTransaction() RetryOption {
+	return func(p *retryPolicy) {
+		p.retrySendTransaction = true
+	}
+}
+
+// WithRetryableCodes makes WithRetry retry requests that fail with one of the given gRPC
+// status codes, instead of Unavailable, DeadlineExceeded and ResourceExhausted.
+//
+// It replaces any predicate set by WithRetryPredicate.
+func WithRetryableCodes(retryable ...codes.Code) RetryOption {
+	return func(p *retryPolicy) {
+		set := make(map[codes.Code]bool, len(retryable))
+		for _, code := range retryable {
+			set[code] = true
+		}
+
+		p.retryable = hasRetryableCode(set)
+	}
+}
+
+// WithRetryPredicate makes WithRetry retry requests whose error is accepted by the given
+// predicate, instead of checking the gRPC status code of the error.
+//
+// The predicate is only called for requests that can be retried, so transaction submissions
+// are still not retried unless the RetrySendTransaction option is given.
+// It replaces any codes set by WithRetryableCodes.
+func WithRetryPredicate(retryable func(err error) bool) RetryOption {
+	return func(p *retryPolicy) {
+		p.retryable = retryable
+	}
+}
+
+// WithRetry returns a dial option that retries requests that fail with a transient error,
+// making at most maxAttempts attempts in total.
+//
+// By default, requests are retried if they fail with the gRPC status code Unavailable,
+// DeadlineExceeded or ResourceExhausted; use WithRetryableCodes or WithRetryPredicate to
+// choose which errors are retried. The delay before the nth retry is drawn at random from
+// [d/2, d], where d = baseDelay * 2^(n-1), capped at 30 seconds.
+//
+// Retries stop when the context of the request is done, and the error of the last attempt
+// is returned. Transaction submissions are only retried if the RetrySendTransaction option is given.
+func WithRetry(maxAttempts int, baseDelay time.Duration, opts ...RetryOption) grpc.DialOption {
+	policy := &retryPolicy{
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		retryable:   hasRetryableCode(defaultRetryableCodes),
+	}
+
+	for _, opt := range opts {
+		opt(policy)
+	}
+
+	return grpc.WithChainUnaryInterceptor(policy.intercept)
+}
+
+type retryPolicy struct {
+	maxAttempts          int
+	baseDelay            time.Duration
+	retryable            func(error) bool
+	retrySendTransaction bool
+}
+
+// delay returns the randomized delay before the given retry, starting at 1.
+func (p *retryPolicy) delay(retry int) time.Duration {
+	d := p.baseDelay
+	for i := 1; i < retry && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+
+	if d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func (p *retryPolicy) intercept(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if _, ok := req.(*access.SendTransactionRequest); ok && !p.retrySendTransaction {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || !p.retryable(err) || attempt >= p.maxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(p.delay(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// WithMethodRateLimits returns a dial option that limits the rate of requests made with
+// each of the given access API methods, such as "GetAccount" or "ExecuteScriptAtLatestBlock".
+//
+// Each method is limited to the given number of requests per second by a token bucket that
+// holds up to one second of requests, so short bursts are allowed. A request over the limit
+// waits until a token is available, and fails with the context error if the context of the
+// request is done first. Methods that are not listed, or that have a limit of zero or less,
+// are not limited.
+func WithMethodRateLimits(limits map[string]int) grpc.DialOption {
+	buckets := make(map[string]*tokenBucket, len(limits))
+
+	for method, limit := range limits {
+		if limit > 0 {
+			buckets[method] = newTokenBucket(limit, time.Now())
+		}
+	}
+
+	return grpc.WithChainUnaryInterceptor(func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		// full method names have the form "/access.AccessAPI/<method>"
+		bucket, ok := buckets[method[strings.LastIndex(method, "/")+1:]]
+		if ok {
+			err := bucket.wait(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
+
+// tokenBucket is a concurrency-safe token bucket that is refilled at a constant rate.
+type tokenBucket struct {
+	mu       sync.Mutex
+	interval time.Duration
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(perSecond int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		interval: time.Second / time.Duration(perSecond),
+		capacity: float64(perSecond),
+		tokens:   float64(perSecond),
+		last:     now,
+	}
+}
+
+// reserve takes a token from the bucket, returning the time to wait until it is available.
+//
+// The bucket may go into debt, so that waiting requests are served in order.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+
+		b.last = now
+	}
+
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
+// cancel returns a reserved token to the bucket.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestDedupCache_Bounded(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	const maxSize = 10
+
+	cache := newDedupCache(time.Hour, maxSize)
+	now := time.Now()
+
+	t.Run("Released IDs are removed", func(t *testing.T) {
+		// a failing node rejects every submission, so every ID is released
+		for i := 0; i < 100*maxSize; i++ {
+			id := ids.New()
+
+			assert.True(t, cache.reserve(id, now))
+			cache.release(id)
+
+			assert.Equal(t, 0, cache.entries.Len())
+			assert.Empty(t, cache.elements)
+		}
+	})
+
+	t.Run("Live IDs are capped", func(t *testing.T) {
+		// an ID that stays recorded keeps the head of the queue live
+		first := ids.New()
+		assert.True(t, cache.reserve(first, now))
+
+		for i := 0; i < 100*maxSize; i++ {
+			id := ids.New()
+
+			assert.True(t, cache.reserve(id, now))
+
+			if i%2 == 0 {
+				cache.release(id)
+			}
+
+			assert.LessOrEqual(t, cache.entries.Len(), maxSize)
+			assert.Equal(t, cache.entries.Len(), len(cache.elements))
+		}
+	})
+
+	t.Run("Released ID can be reserved again", func(t *testing.T) {
+		id := ids.New()
+
+		assert.True(t, cache.reserve(id, now))
+		assert.False(t, cache.reserve(id, now))
+
+		cache.release(id)
+
+		assert.True(t, cache.reserve(id, now))
+	})
+}
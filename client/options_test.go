@@ -0,0 +1,496 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestWithDialTimeout(t *testing.T) {
+	t.Run("Unreachable", func(t *testing.T) {
+		start := time.Now()
+
+		// reserved for documentation, so never routable
+		c, err := client.New(
+			"192.0.2.1:3569",
+			grpc.WithInsecure(),
+			client.WithDialTimeout(100*time.Millisecond),
+		)
+		require.Error(t, err)
+		assert.Nil(t, c)
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		// without a timeout the dial does not block, so construction succeeds
+		c, err := client.New("192.0.2.1:3569", grpc.WithInsecure())
+		require.NoError(t, err)
+
+		assert.NoError(t, c.Close())
+	})
+}
+
+// sendTransactionServer is an in-process access API server that counts transaction submissions.
+type sendTransactionServer struct {
+	access.UnimplementedAccessAPIServer
+
+	mu    sync.Mutex
+	sends int
+	err   error
+}
+
+func (s *sendTransactionServer) SendTransaction(
+	_ context.Context,
+	req *access.SendTransactionRequest,
+) (*access.SendTransactionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sends++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &access.SendTransactionResponse{}, nil
+}
+
+func (s *sendTransactionServer) Sends() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sends
+}
+
+func (s *sendTransactionServer) SetErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// newBufconnClient returns a client connected to srv over an in-memory connection,
+// and a function that closes the client and stops the server.
+func newBufconnClient(
+	t *testing.T,
+	srv access.AccessAPIServer,
+	opts ...grpc.DialOption,
+) (*client.Client, func()) {
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	access.RegisterAccessAPIServer(server, srv)
+
+	go func() { _ = server.Serve(lis) }()
+
+	opts = append(
+		opts,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+
+	c, err := client.New("bufnet", opts...)
+	if err != nil {
+		server.Stop()
+	}
+	require.NoError(t, err)
+
+	return c, func() {
+		_ = c.Close()
+		server.Stop()
+	}
+}
+
+func TestWithDedupCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Duplicate within TTL", func(t *testing.T) {
+		srv := &sendTransactionServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithDedupCache(time.Minute))
+		defer cleanup()
+
+		tx := test.TransactionGenerator().New()
+
+		err := c.SendTransaction(ctx, *tx)
+		require.NoError(t, err)
+
+		err = c.SendTransaction(ctx, *tx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, client.ErrDuplicateSubmission))
+
+		var dupErr client.DuplicateSubmissionError
+		require.True(t, errors.As(err, &dupErr))
+		assert.Equal(t, tx.ID(), dupErr.TransactionID)
+
+		assert.Equal(t, 1, srv.Sends())
+	})
+
+	t.Run("Different transactions", func(t *testing.T) {
+		srv := &sendTransactionServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithDedupCache(time.Minute))
+		defer cleanup()
+
+		transactions := test.TransactionGenerator()
+
+		err := c.SendTransaction(ctx, *transactions.New().SetGasLimit(1))
+		require.NoError(t, err)
+
+		err = c.SendTransaction(ctx, *transactions.New().SetGasLimit(2))
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, srv.Sends())
+	})
+
+	t.Run("Duplicate after TTL", func(t *testing.T) {
+		srv := &sendTransactionServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithDedupCache(10*time.Millisecond))
+		defer cleanup()
+
+		tx := test.TransactionGenerator().New()
+
+		err := c.SendTransaction(ctx, *tx)
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		err = c.SendTransaction(ctx, *tx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, srv.Sends())
+	})
+
+	t.Run("Resend after failure", func(t *testing.T) {
+		srv := &sendTransactionServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithDedupCache(time.Minute))
+		defer cleanup()
+
+		tx := test.TransactionGenerator().New()
+
+		srv.SetErr(status.Error(codes.Unavailable, "unavailable"))
+
+		err := c.SendTransaction(ctx, *tx)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, client.ErrDuplicateSubmission))
+
+		srv.SetErr(nil)
+
+		err = c.SendTransaction(ctx, *tx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, srv.Sends())
+	})
+}
+
+type contextKey string
+
+func TestClient_InterceptorContext(t *testing.T) {
+	const key = contextKey("correlationID")
+
+	var (
+		mu     sync.Mutex
+		values = make(map[string]interface{})
+	)
+
+	interceptor := func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		mu.Lock()
+		values[method] = ctx.Value(key)
+		mu.Unlock()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	c, cleanup := newBufconnClient(t, &sendTransactionServer{}, grpc.WithUnaryInterceptor(interceptor))
+	defer cleanup()
+
+	ctx := context.WithValue(context.Background(), key, "abc123")
+
+	// the test server only implements SendTransaction, but interceptors run regardless
+	_ = c.Ping(ctx)
+	_, _ = c.ExecuteScriptAtLatestBlock(ctx, []byte("pub fun main() {}"))
+	_, errs := c.SendTransactions(ctx, []*flow.Transaction{test.TransactionGenerator().New()})
+	require.NoError(t, errs[0])
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, method := range []string{
+		"/access.AccessAPI/Ping",
+		"/access.AccessAPI/ExecuteScriptAtLatestBlock",
+		"/access.AccessAPI/SendTransaction",
+	} {
+		assert.Equal(t, "abc123", values[method], method)
+	}
+}
+
+// flakyPingServer is an in-process access API server that fails pings with an error
+// until a given number of failures has been returned.
+type flakyPingServer struct {
+	access.UnimplementedAccessAPIServer
+
+	mu       sync.Mutex
+	pings    int
+	failures int
+	err      error
+}
+
+func (s *flakyPingServer) Ping(context.Context, *access.PingRequest) (*access.PingResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pings++
+
+	if s.pings <= s.failures {
+		return nil, s.err
+	}
+
+	return &access.PingResponse{}, nil
+}
+
+func (s *flakyPingServer) Pings() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pings
+}
+
+func TestWithRetry(t *testing.T) {
+	ctx := context.Background()
+
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	t.Run("Transient errors", func(t *testing.T) {
+		srv := &flakyPingServer{failures: 2, err: unavailable}
+		c, cleanup := newBufconnClient(t, srv, client.WithRetry(3, time.Millisecond))
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, srv.Pings())
+	})
+
+	t.Run("Attempts exhausted", func(t *testing.T) {
+		srv := &flakyPingServer{failures: 5, err: unavailable}
+		c, cleanup := newBufconnClient(t, srv, client.WithRetry(3, time.Millisecond))
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		assert.Equal(t, 3, srv.Pings())
+	})
+
+	t.Run("Permanent error", func(t *testing.T) {
+		srv := &flakyPingServer{failures: 1, err: status.Error(codes.InvalidArgument, "invalid")}
+		c, cleanup := newBufconnClient(t, srv, client.WithRetry(3, time.Millisecond))
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.Error(t, err)
+
+		assert.Equal(t, 1, srv.Pings())
+	})
+
+	t.Run("Context deadline", func(t *testing.T) {
+		srv := &flakyPingServer{failures: 5, err: unavailable}
+		c, cleanup := newBufconnClient(t, srv, client.WithRetry(5, time.Minute))
+		defer cleanup()
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		err := c.Ping(ctx)
+		require.Error(t, err)
+
+		assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+		assert.Equal(t, 1, srv.Pings())
+	})
+
+	t.Run("Retryable codes", func(t *testing.T) {
+		srv := &flakyPingServer{failures: 2, err: status.Error(codes.Internal, "reindexing")}
+		c, cleanup := newBufconnClient(
+			t,
+			srv,
+			client.WithRetry(3, time.Millisecond, client.WithRetryableCodes(codes.Internal)),
+		)
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, srv.Pings())
+	})
+
+	t.Run("Retryable codes replace defaults", func(t *testing.T) {
+		srv := &flakyPingServer{failures: 2, err: unavailable}
+		c, cleanup := newBufconnClient(
+			t,
+			srv,
+			client.WithRetry(3, time.Millisecond, client.WithRetryableCodes(codes.Internal)),
+		)
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.Error(t, err)
+
+		assert.Equal(t, 1, srv.Pings())
+	})
+
+	t.Run("Retry predicate", func(t *testing.T) {
+		isReindexing := func(err error) bool {
+			return status.Code(err) == codes.Internal && strings.Contains(err.Error(), "reindexing")
+		}
+
+		srv := &flakyPingServer{failures: 2, err: status.Error(codes.Internal, "reindexing")}
+		c, cleanup := newBufconnClient(
+			t,
+			srv,
+			client.WithRetry(3, time.Millisecond, client.WithRetryPredicate(isReindexing)),
+		)
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, srv.Pings())
+	})
+
+	t.Run("Retry predicate does not retry send transaction", func(t *testing.T) {
+		srv := &sendTransactionServer{err: status.Error(codes.Internal, "reindexing")}
+		c, cleanup := newBufconnClient(
+			t,
+			srv,
+			client.WithRetry(3, time.Millisecond, client.WithRetryPredicate(func(error) bool { return true })),
+		)
+		defer cleanup()
+
+		err := c.SendTransaction(ctx, *test.TransactionGenerator().New())
+		require.Error(t, err)
+
+		assert.Equal(t, 1, srv.Sends())
+	})
+
+	t.Run("Send transaction not retried by default", func(t *testing.T) {
+		srv := &sendTransactionServer{err: unavailable}
+		c, cleanup := newBufconnClient(t, srv, client.WithRetry(3, time.Millisecond))
+		defer cleanup()
+
+		err := c.SendTransaction(ctx, *test.TransactionGenerator().New())
+		require.Error(t, err)
+
+		assert.Equal(t, 1, srv.Sends())
+	})
+
+	t.Run("Send transaction opt-in", func(t *testing.T) {
+		srv := &sendTransactionServer{err: unavailable}
+		c, cleanup := newBufconnClient(t, srv, client.WithRetry(3, time.Millisecond, client.RetrySendTransaction()))
+		defer cleanup()
+
+		err := c.SendTransaction(ctx, *test.TransactionGenerator().New())
+		require.Error(t, err)
+
+		assert.Equal(t, 3, srv.Sends())
+	})
+}
+
+func TestWithMethodRateLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Pacing", func(t *testing.T) {
+		srv := &flakyPingServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithMethodRateLimits(map[string]int{"Ping": 20}))
+		defer cleanup()
+
+		start := time.Now()
+
+		// the first 20 requests use the burst, the following 10 are paced at 50ms intervals
+		for i := 0; i < 30; i++ {
+			err := c.Ping(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(450*time.Millisecond))
+		assert.Equal(t, 30, srv.Pings())
+	})
+
+	t.Run("Context deadline", func(t *testing.T) {
+		srv := &flakyPingServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithMethodRateLimits(map[string]int{"Ping": 1}))
+		defer cleanup()
+
+		err := c.Ping(ctx)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		err = c.Ping(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		assert.Equal(t, 1, srv.Pings())
+	})
+
+	t.Run("Unlisted method", func(t *testing.T) {
+		srv := &flakyPingServer{}
+		c, cleanup := newBufconnClient(t, srv, client.WithMethodRateLimits(map[string]int{"GetAccount": 1}))
+		defer cleanup()
+
+		start := time.Now()
+
+		for i := 0; i < 10; i++ {
+			err := c.Ping(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+		assert.Equal(t, 10, srv.Pings())
+	})
+}
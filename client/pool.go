@@ -0,0 +1,430 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// defaultMaxSequenceRetries is the default number of times a transaction pool resubmits a
+// transaction that was rejected because of its proposal key sequence number.
+const defaultMaxSequenceRetries = 3
+
+// A TransactionIntent describes a transaction to be built, signed and submitted by a
+// transaction pool.
+type TransactionIntent struct {
+	Script   []byte
+	GasLimit uint64
+	// If true, the pool account is the authorizer of the transaction.
+	Authorize bool
+}
+
+// A PoolKey is an account key used by a transaction pool to propose and pay for transactions.
+type PoolKey struct {
+	KeyID  int
+	Signer crypto.Signer
+}
+
+type poolOptions struct {
+	maxInFlight int
+	maxRetries  int
+	waitOptions []WaitOption
+}
+
+// A PoolOption configures the behaviour of a transaction pool.
+type PoolOption func(*poolOptions)
+
+// WithMaxInFlight sets the maximum number of transactions submitted by the pool that
+// are not yet sealed. Submit blocks while this many transactions are in flight.
+//
+// The default maximum is the number of keys in the pool.
+func WithMaxInFlight(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.maxInFlight = n
+	}
+}
+
+// WithMaxSequenceRetries sets the number of times a transaction rejected because of its
+// proposal key sequence number is resubmitted with a resynchronized sequence number.
+//
+// The default is three retries.
+func WithMaxSequenceRetries(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.maxRetries = n
+	}
+}
+
+// WithPoolWaitOptions sets the options used when waiting for submitted transactions to be sealed.
+func WithPoolWaitOptions(opts ...WaitOption) PoolOption {
+	return func(o *poolOptions) {
+		o.waitOptions = opts
+	}
+}
+
+type poolKey struct {
+	PoolKey
+	sequenceNumber uint64
+	synced         bool
+	syncing        bool
+	// the number of transactions proposed with this key that are not yet sealed or failed
+	inFlight int
+
+	// signers such as crypto.InMemorySigner are not safe for concurrent use
+	signMu sync.Mutex
+}
+
+// A TransactionPool submits transactions on behalf of a single account, using a set of
+// its keys as proposal keys.
+//
+// The pool account is the proposer and payer of every transaction. Keys are used in turn,
+// and the pool tracks the next sequence number of each key so that several transactions
+// can be in flight at once. The sequence number of a key is read from the network when
+// the key is first used, and again after a transaction proposed with it fails.
+//
+// A failed transaction leaves a gap in the sequence numbers of its key, so the key is not
+// used again until all the other transactions proposed with it are sealed or have failed,
+// and its sequence number is then read from the network.
+//
+// A TransactionPool is safe for concurrent use.
+type TransactionPool struct {
+	client  *Client
+	address flow.Address
+	options poolOptions
+	slots   chan struct{}
+
+	mu   sync.Mutex
+	keys []*poolKey
+	next int
+	// closed and replaced when a key may have become available
+	released chan struct{}
+}
+
+// NewTransactionPool returns a transaction pool that submits transactions for the given
+// account, proposed and signed with the given keys.
+//
+// An error is returned if no keys are given, if a key is given more than once, or if the
+// options are invalid.
+func NewTransactionPool(
+	c *Client,
+	address flow.Address,
+	keys []PoolKey,
+	opts ...PoolOption,
+) (*TransactionPool, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("client: transaction pool requires at least one key")
+	}
+
+	options := poolOptions{
+		maxInFlight: len(keys),
+		maxRetries:  defaultMaxSequenceRetries,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.maxInFlight < 1 {
+		return nil, fmt.Errorf("client: invalid maximum in-flight transactions %d", options.maxInFlight)
+	}
+
+	if options.maxRetries < 0 {
+		return nil, fmt.Errorf("client: invalid maximum sequence retries %d", options.maxRetries)
+	}
+
+	poolKeys := make([]*poolKey, len(keys))
+	seen := make(map[int]bool, len(keys))
+
+	for i, key := range keys {
+		if seen[key.KeyID] {
+			return nil, fmt.Errorf("client: key %d is given more than once", key.KeyID)
+		}
+
+		seen[key.KeyID] = true
+		poolKeys[i] = &poolKey{PoolKey: key}
+	}
+
+	return &TransactionPool{
+		client:   c,
+		address:  address,
+		options:  options,
+		slots:    make(chan struct{}, options.maxInFlight),
+		keys:     poolKeys,
+		released: make(chan struct{}),
+	}, nil
+}
+
+// Submit builds, signs and sends a transaction for the given intent, and returns a channel
+// that receives its result once it is sealed.
+//
+// Submit blocks until the number of transactions in flight is below the pool maximum,
+// and returns an error if ctx is done first or if the transaction cannot be sent.
+//
+// A transaction that is rejected because of its sequence number is resubmitted as a new
+// transaction, so the delivered result may belong to a different transaction than the one
+// first sent. If ctx is done or the result cannot be retrieved before the transaction
+// is sealed, the channel receives a result with an unknown status and the error.
+// The channel is closed after the result is delivered.
+func (p *TransactionPool) Submit(ctx context.Context, intent TransactionIntent) (<-chan flow.TransactionResult, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	txID, key, err := p.send(ctx, intent)
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+
+	results := make(chan flow.TransactionResult, 1)
+
+	go func() {
+		defer func() { <-p.slots }()
+		defer close(results)
+
+		results <- p.wait(ctx, intent, txID, key)
+	}()
+
+	return results, nil
+}
+
+// send sends a transaction for the intent, retrying if it is rejected because of its
+// sequence number.
+func (p *TransactionPool) send(ctx context.Context, intent TransactionIntent) (flow.Identifier, *poolKey, error) {
+	for attempt := 0; ; attempt++ {
+		txID, key, err := p.sendOnce(ctx, intent)
+		if err == nil {
+			return txID, key, nil
+		}
+
+		if !isSequenceNumberError(err) || attempt >= p.options.maxRetries {
+			return flow.ZeroID, nil, err
+		}
+	}
+}
+
+func (p *TransactionPool) sendOnce(ctx context.Context, intent TransactionIntent) (flow.Identifier, *poolKey, error) {
+	header, err := p.client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return flow.ZeroID, nil, err
+	}
+
+	key, sequenceNumber, err := p.reserve(ctx)
+	if err != nil {
+		return flow.ZeroID, nil, err
+	}
+
+	tx := flow.NewTransaction().
+		SetScript(intent.Script).
+		SetGasLimit(intent.GasLimit).
+		SetReferenceBlockID(header.ID).
+		SetProposalKey(p.address, key.KeyID, sequenceNumber).
+		SetPayer(p.address)
+
+	if intent.Authorize {
+		tx.AddAuthorizer(p.address)
+	}
+
+	key.signMu.Lock()
+	err = tx.SignEnvelopeContext(ctx, p.address, key.KeyID, key.Signer)
+	key.signMu.Unlock()
+
+	if err == nil {
+		err = p.client.SendTransaction(ctx, *tx)
+	}
+
+	if err != nil {
+		p.release(key, true)
+		return flow.ZeroID, nil, err
+	}
+
+	return tx.ID(), key, nil
+}
+
+// wait waits for a transaction to be sealed, resubmitting the intent if the transaction
+// is rejected because of its sequence number.
+func (p *TransactionPool) wait(
+	ctx context.Context,
+	intent TransactionIntent,
+	txID flow.Identifier,
+	key *poolKey,
+) flow.TransactionResult {
+	for attempt := 0; ; attempt++ {
+		result, err := p.client.WaitForSeal(ctx, txID, p.options.waitOptions...)
+		if err != nil {
+			// the transaction may still be sealed later, so its sequence number is unknown
+			p.release(key, true)
+			return flow.TransactionResult{Status: flow.TransactionStatusUnknown, Error: err}
+		}
+
+		rejected := isSequenceNumberError(result.Error)
+		p.release(key, rejected)
+
+		if !rejected || attempt >= p.options.maxRetries {
+			return *result
+		}
+
+		txID, key, err = p.send(ctx, intent)
+		if err != nil {
+			return flow.TransactionResult{Status: flow.TransactionStatusUnknown, Error: err}
+		}
+	}
+}
+
+// reserve selects the next available key of the pool and reserves its next sequence number,
+// blocking until a key is available or ctx is done.
+//
+// A key is available if its sequence number is known, or if it has no transactions in flight
+// and can be synchronized with the network. Only one reservation synchronizes a key at a time,
+// and the network request is made without holding the pool lock.
+func (p *TransactionPool) reserve(ctx context.Context) (*poolKey, uint64, error) {
+	for {
+		p.mu.Lock()
+		key := p.availableKey()
+
+		if key == nil {
+			released := p.released
+			p.mu.Unlock()
+
+			select {
+			case <-released:
+				continue
+			case <-ctx.Done():
+				return nil, 0, ctx.Err()
+			}
+		}
+
+		key.inFlight++
+
+		if key.synced {
+			sequenceNumber := key.sequenceNumber
+			key.sequenceNumber++
+			p.mu.Unlock()
+
+			return key, sequenceNumber, nil
+		}
+
+		key.syncing = true
+		p.mu.Unlock()
+
+		sequenceNumber, err := p.fetchSequenceNumber(ctx, key.KeyID)
+
+		p.mu.Lock()
+		key.syncing = false
+
+		if err != nil {
+			p.mu.Unlock()
+			p.release(key, false)
+			return nil, 0, err
+		}
+
+		key.sequenceNumber = sequenceNumber + 1
+		key.synced = true
+		p.notify()
+		p.mu.Unlock()
+
+		return key, sequenceNumber, nil
+	}
+}
+
+// availableKey returns the next key in turn that can be reserved, or nil if there is none.
+// It must be called with the pool lock held.
+func (p *TransactionPool) availableKey() *poolKey {
+	for i := 0; i < len(p.keys); i++ {
+		key := p.keys[(p.next+i)%len(p.keys)]
+
+		if key.syncing || (!key.synced && key.inFlight > 0) {
+			continue
+		}
+
+		p.next = (p.next + i + 1) % len(p.keys)
+		return key
+	}
+
+	return nil
+}
+
+func (p *TransactionPool) fetchSequenceNumber(ctx context.Context, keyID int) (uint64, error) {
+	account, err := p.client.GetAccount(ctx, p.address)
+	if err != nil {
+		return 0, err
+	}
+
+	accountKey := findAccountKey(account.Keys, keyID)
+	if accountKey == nil {
+		return 0, fmt.Errorf("client: account %s has no key %d", p.address, keyID)
+	}
+
+	return accountKey.SequenceNumber, nil
+}
+
+// release ends a transaction proposed with the key. If the transaction failed, the sequence
+// numbers handed out after it are invalid, so the key is resynchronized once it has no
+// transactions in flight.
+func (p *TransactionPool) release(key *poolKey, failed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key.inFlight--
+
+	if failed {
+		key.synced = false
+	}
+
+	p.notify()
+}
+
+// notify wakes reservations waiting for a key. It must be called with the pool lock held.
+func (p *TransactionPool) notify() {
+	close(p.released)
+	p.released = make(chan struct{})
+}
+
+func findAccountKey(keys []*flow.AccountKey, keyID int) *flow.AccountKey {
+	for _, key := range keys {
+		if key.ID == keyID {
+			return key
+		}
+	}
+
+	return nil
+}
+
+// sequenceNumberErrorText is contained in the error message of a transaction rejected
+// because of the sequence number of its proposal key.
+//
+// The Access API does not report the reason for a rejection as a code, neither in the gRPC
+// status of SendTransaction nor in the transaction result, so the message text is the only
+// way to recognize these rejections. A rejection that is not recognized is returned to the
+// caller instead of being retried.
+const sequenceNumberErrorText = "sequence number"
+
+// isSequenceNumberError reports whether err is a rejection of a transaction because of
+// the sequence number of its proposal key.
+func isSequenceNumberError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), sequenceNumberErrorText)
+}
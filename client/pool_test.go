@@ -0,0 +1,389 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type proposalKey struct {
+	keyID          int
+	sequenceNumber uint64
+}
+
+// poolTest mocks an access node for a transaction pool and records the proposal keys
+// of the transactions sent to it.
+type poolTest struct {
+	rpc     *mocks.RPCClient
+	account *flow.Account
+	keys    []client.PoolKey
+
+	mu   sync.Mutex
+	sent []proposalKey
+}
+
+func newPoolTest(t *testing.T, keyCount int) *poolTest {
+	accountKeys := test.AccountKeyGenerator()
+
+	account := test.AccountGenerator().New()
+	account.Keys = nil
+
+	keys := make([]client.PoolKey, keyCount)
+
+	for i := range keys {
+		accountKey, signer := accountKeys.NewWithSigner()
+		account.Keys = append(account.Keys, accountKey)
+		keys[i] = client.PoolKey{KeyID: accountKey.ID, Signer: signer}
+	}
+
+	rpc := &mocks.RPCClient{}
+
+	rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+		Return(&access.BlockHeaderResponse{
+			Block: convert.BlockHeaderToMessage(test.BlockGenerator().New().BlockHeader),
+		}, nil)
+
+	return &poolTest{
+		rpc:     rpc,
+		account: account,
+		keys:    keys,
+	}
+}
+
+func (p *poolTest) accountResponse(t *testing.T, sequenceNumber uint64) *access.GetAccountResponse {
+	for _, key := range p.account.Keys {
+		key.SequenceNumber = sequenceNumber
+	}
+
+	account, err := convert.AccountToMessage(*p.account)
+	require.NoError(t, err)
+
+	return &access.GetAccountResponse{Account: account}
+}
+
+func (p *poolTest) resultStatus(t *testing.T, status flow.TransactionStatus) {
+	result, err := convert.TransactionResultToMessage(flow.TransactionResult{Status: status})
+	require.NoError(t, err)
+
+	p.rpc.On("GetTransactionResult", mock.Anything, mock.Anything).Return(result, nil)
+}
+
+func (p *poolTest) record(args mock.Arguments) {
+	req := args.Get(1).(*access.SendTransactionRequest)
+
+	tx, err := convert.MessageToTransaction(req.GetTransaction())
+	if err != nil {
+		panic(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sent = append(p.sent, proposalKey{tx.ProposalKey.KeyID, tx.ProposalKey.SequenceNumber})
+}
+
+func (p *poolTest) newPool(t *testing.T, opts ...client.PoolOption) *client.TransactionPool {
+	opts = append(opts, client.WithPoolWaitOptions(client.WithPollInterval(time.Millisecond)))
+
+	pool, err := client.NewTransactionPool(client.NewFromRPCClient(p.rpc), p.account.Address, p.keys, opts...)
+	require.NoError(t, err)
+
+	return pool
+}
+
+func TestTransactionPool_Submit(t *testing.T) {
+	intent := client.TransactionIntent{
+		Script:    []byte(`transaction { prepare(signer: AuthAccount) {} }`),
+		GasLimit:  42,
+		Authorize: true,
+	}
+
+	t.Run("Concurrent submits", func(t *testing.T) {
+		const submits = 50
+
+		pt := newPoolTest(t, 3)
+		pt.resultStatus(t, flow.TransactionStatusSealed)
+
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 7), nil)
+
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(pt.record).
+			Return(&access.SendTransactionResponse{}, nil)
+
+		pool := pt.newPool(t, client.WithMaxInFlight(5))
+
+		ctx := context.Background()
+
+		var wg sync.WaitGroup
+		results := make(chan flow.TransactionResult, submits)
+
+		for i := 0; i < submits; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				result, err := pool.Submit(ctx, intent)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				results <- <-result
+			}()
+		}
+
+		wg.Wait()
+		close(results)
+
+		sealed := 0
+		for result := range results {
+			assert.NoError(t, result.Error)
+			assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+			sealed++
+		}
+
+		assert.Equal(t, submits, sealed)
+
+		// every key is synchronized once, and its sequence numbers are used in order without gaps
+		pt.rpc.AssertNumberOfCalls(t, "GetAccount", len(pt.keys))
+
+		seen := make(map[proposalKey]bool)
+		counts := make(map[int]uint64)
+
+		for _, key := range pt.sent {
+			assert.False(t, seen[key], "sequence number %d of key %d used twice", key.sequenceNumber, key.keyID)
+			seen[key] = true
+			counts[key.keyID]++
+		}
+
+		assert.Len(t, seen, submits)
+
+		for keyID, count := range counts {
+			for sequenceNumber := uint64(7); sequenceNumber < 7+count; sequenceNumber++ {
+				assert.True(t, seen[proposalKey{keyID, sequenceNumber}])
+			}
+		}
+	})
+
+	t.Run("Resynchronizes after sequence number error", func(t *testing.T) {
+		pt := newPoolTest(t, 1)
+		pt.resultStatus(t, flow.TransactionStatusSealed)
+
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 0), nil).
+			Once()
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 5), nil).
+			Once()
+
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(pt.record).
+			Return(nil, errors.New("invalid proposal key: sequence number mismatch")).
+			Once()
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(pt.record).
+			Return(&access.SendTransactionResponse{}, nil).
+			Once()
+
+		pool := pt.newPool(t)
+
+		results, err := pool.Submit(context.Background(), intent)
+		require.NoError(t, err)
+
+		result := <-results
+		assert.NoError(t, result.Error)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+
+		keyID := pt.keys[0].KeyID
+		assert.Equal(t, []proposalKey{{keyID, 0}, {keyID, 5}}, pt.sent)
+
+		pt.rpc.AssertExpectations(t)
+	})
+
+	t.Run("Resynchronizes only without transactions in flight", func(t *testing.T) {
+		pt := newPoolTest(t, 1)
+
+		var sealed int32
+
+		pending, err := convert.TransactionResultToMessage(flow.TransactionResult{
+			Status: flow.TransactionStatusPending,
+		})
+		require.NoError(t, err)
+
+		sealedResult, err := convert.TransactionResultToMessage(flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+		})
+		require.NoError(t, err)
+
+		pt.rpc.On("GetTransactionResult", mock.Anything, mock.Anything).
+			Return(
+				func(context.Context, *access.GetTransactionRequest, ...grpc.CallOption) *access.TransactionResultResponse {
+					if atomic.LoadInt32(&sealed) == 1 {
+						return sealedResult
+					}
+					return pending
+				},
+				nil,
+			)
+
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 0), nil).
+			Once()
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 1), nil).
+			Once()
+
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(pt.record).
+			Return(&access.SendTransactionResponse{}, nil).
+			Once()
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(pt.record).
+			Return(nil, errors.New("rpc error")).
+			Once()
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(pt.record).
+			Return(&access.SendTransactionResponse{}, nil).
+			Once()
+
+		pool := pt.newPool(t, client.WithMaxInFlight(3))
+
+		ctx := context.Background()
+
+		first, err := pool.Submit(ctx, intent)
+		require.NoError(t, err)
+
+		_, err = pool.Submit(ctx, intent)
+		require.Error(t, err)
+
+		// the key cannot be resynchronized while the first transaction is in flight
+		timeoutCtx, cancelTimeout := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancelTimeout()
+
+		_, err = pool.Submit(timeoutCtx, intent)
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		pt.rpc.AssertNumberOfCalls(t, "GetAccount", 1)
+
+		atomic.StoreInt32(&sealed, 1)
+		assert.Equal(t, flow.TransactionStatusSealed, (<-first).Status)
+
+		third, err := pool.Submit(ctx, intent)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, (<-third).Status)
+
+		keyID := pt.keys[0].KeyID
+		assert.Equal(t, []proposalKey{{keyID, 0}, {keyID, 1}, {keyID, 1}}, pt.sent)
+
+		pt.rpc.AssertExpectations(t)
+	})
+
+	t.Run("Send error", func(t *testing.T) {
+		pt := newPoolTest(t, 1)
+
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 0), nil)
+
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		pool := pt.newPool(t)
+
+		results, err := pool.Submit(context.Background(), intent)
+		assert.Error(t, err)
+		assert.Nil(t, results)
+
+		pt.rpc.AssertNumberOfCalls(t, "SendTransaction", 1)
+	})
+
+	t.Run("Context done while at capacity", func(t *testing.T) {
+		pt := newPoolTest(t, 1)
+		pt.resultStatus(t, flow.TransactionStatusPending)
+
+		pt.rpc.On("GetAccount", mock.Anything, mock.Anything).
+			Return(pt.accountResponse(t, 0), nil)
+
+		pt.rpc.On("SendTransaction", mock.Anything, mock.Anything).
+			Return(&access.SendTransactionResponse{}, nil)
+
+		pool := pt.newPool(t, client.WithMaxInFlight(1))
+
+		// the first transaction is never sealed, so it holds the only slot
+		ctx, cancel := context.WithCancel(context.Background())
+
+		results, err := pool.Submit(ctx, intent)
+		require.NoError(t, err)
+
+		timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancelTimeout()
+
+		_, err = pool.Submit(timeoutCtx, intent)
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		cancel()
+
+		result := <-results
+		assert.Equal(t, flow.TransactionStatusUnknown, result.Status)
+		assert.Equal(t, context.Canceled, result.Error)
+	})
+}
+
+func TestNewTransactionPool(t *testing.T) {
+	c := client.NewFromRPCClient(&mocks.RPCClient{})
+	address := test.AddressGenerator().New()
+
+	_, signer := test.AccountKeyGenerator().NewWithSigner()
+
+	t.Run("No keys", func(t *testing.T) {
+		_, err := client.NewTransactionPool(c, address, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Duplicate key", func(t *testing.T) {
+		key := client.PoolKey{KeyID: 0, Signer: signer}
+
+		_, err := client.NewTransactionPool(c, address, []client.PoolKey{key, key})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid maximum in flight", func(t *testing.T) {
+		key := client.PoolKey{KeyID: 0, Signer: signer}
+
+		_, err := client.NewTransactionPool(c, address, []client.PoolKey{key}, client.WithMaxInFlight(0))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,306 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// maxSubscriptionRange is the maximum number of blocks queried in a single request by an
+// event subscription.
+const maxSubscriptionRange = 250
+
+// defaultReconnectDelay is the default base delay before an event subscription retries
+// a request that failed with a transient error.
+const defaultReconnectDelay = time.Second
+
+// An EventBatch contains the events matching a subscription that were emitted in a sealed block.
+type EventBatch = BlockEvents
+
+// EventFilter selects the events delivered by an event subscription.
+type EventFilter struct {
+	// The event types to subscribe to.
+	Types []string
+	// The accounts whose contracts declare the event types. If empty, no filtering by address is done.
+	//
+	// If no types are given, the subscription includes all the event types declared by the
+	// contracts deployed to these accounts when the subscription starts.
+	Addresses []flow.Address
+	// The block height to start delivering events from. If zero, the subscription starts
+	// at the latest sealed block.
+	StartHeight uint64
+}
+
+type subscribeOptions struct {
+	pollInterval   time.Duration
+	reconnectDelay time.Duration
+}
+
+// A SubscribeOption configures the behaviour of an event subscription.
+type SubscribeOption func(*subscribeOptions)
+
+// WithSubscriptionPollInterval sets the interval between requests for new sealed blocks.
+//
+// The default interval is one second.
+func WithSubscriptionPollInterval(interval time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// WithReconnectDelay sets the base delay before a request that failed with a transient
+// error is retried. The delay doubles after each consecutive failure, up to 30 seconds.
+//
+// The default delay is one second.
+func WithReconnectDelay(delay time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.reconnectDelay = delay
+	}
+}
+
+// SubscribeEvents delivers the events matching the filter as blocks are sealed.
+//
+// Version 0.1.3 of the Access API does not provide an event streaming RPC, so the
+// subscription polls the access node for new sealed blocks. Each batch contains the events
+// of one block, and batches are delivered in order of height. Blocks without matching
+// events are skipped.
+//
+// Requests that fail with a transient error, such as an unavailable node, are retried with
+// exponential backoff. A block range is only delivered once all of its events have been
+// received, and delivery resumes after the last delivered height, so no events are delivered
+// twice. Any other error is sent on the error channel and ends the subscription.
+//
+// Both channels are closed when the subscription ends, including when ctx is done.
+func (c *Client) SubscribeEvents(
+	ctx context.Context,
+	filter EventFilter,
+	opts ...SubscribeOption,
+) (<-chan EventBatch, <-chan error) {
+	options := subscribeOptions{
+		pollInterval:   defaultPollInterval,
+		reconnectDelay: defaultReconnectDelay,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	batches := make(chan EventBatch)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(batches)
+
+		err := c.subscribeEvents(ctx, filter, options, batches)
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return batches, errs
+}
+
+func (c *Client) subscribeEvents(
+	ctx context.Context,
+	filter EventFilter,
+	options subscribeOptions,
+	batches chan<- EventBatch,
+) error {
+	reconnect := func(f func() error) error {
+		return retryTransient(ctx, options.reconnectDelay, f)
+	}
+
+	var eventTypes []string
+
+	err := reconnect(func() (err error) {
+		eventTypes, err = c.subscriptionEventTypes(ctx, filter)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(eventTypes) == 0 {
+		return errors.New("client: event filter does not match any event type")
+	}
+
+	next := filter.StartHeight
+
+	for {
+		var latest uint64
+
+		err := reconnect(func() error {
+			header, err := c.GetLatestBlockHeader(ctx, true)
+			if err != nil {
+				return err
+			}
+
+			latest = header.Height
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if next == 0 {
+			next = latest
+		}
+
+		for next <= latest {
+			end := latest
+			if end-next >= maxSubscriptionRange {
+				end = next + maxSubscriptionRange - 1
+			}
+
+			var blocks []BlockEvents
+
+			err := reconnect(func() error {
+				results := make([][]BlockEvents, 0, len(eventTypes))
+
+				for _, eventType := range eventTypes {
+					result, err := c.GetEventsForHeightRange(ctx, EventRangeQuery{
+						Type:        eventType,
+						StartHeight: next,
+						EndHeight:   end,
+					})
+					if err != nil {
+						return err
+					}
+
+					results = append(results, result)
+				}
+
+				blocks = mergeBlockEvents(results)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, block := range blocks {
+				if block.Height < next || block.Height > end || len(block.Events) == 0 {
+					continue
+				}
+
+				select {
+				case batches <- block:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			next = end + 1
+		}
+
+		timer := time.NewTimer(options.pollInterval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// subscriptionEventTypes returns the event types to query for the given filter.
+func (c *Client) subscriptionEventTypes(ctx context.Context, filter EventFilter) ([]string, error) {
+	if len(filter.Addresses) == 0 {
+		return filter.Types, nil
+	}
+
+	if len(filter.Types) == 0 {
+		eventTypes := make([]string, 0)
+
+		for _, address := range filter.Addresses {
+			account, err := c.GetAccount(ctx, address)
+			if err != nil {
+				return nil, err
+			}
+
+			accountTypes, err := accountEventTypes(address, account.Code)
+			if err != nil {
+				return nil, fmt.Errorf("client: %w", err)
+			}
+
+			eventTypes = append(eventTypes, accountTypes...)
+		}
+
+		return eventTypes, nil
+	}
+
+	eventTypes := make([]string, 0, len(filter.Types))
+
+	for _, eventType := range filter.Types {
+		for _, address := range filter.Addresses {
+			if strings.HasPrefix(eventType, fmt.Sprintf("A.%s.", address.Hex())) {
+				eventTypes = append(eventTypes, eventType)
+				break
+			}
+		}
+	}
+
+	return eventTypes, nil
+}
+
+// retryTransient calls f until it succeeds or fails with an error that is not transient,
+// waiting with exponential backoff between attempts.
+//
+// If ctx is done while waiting, the error of the last attempt is returned.
+func retryTransient(ctx context.Context, baseDelay time.Duration, f func() error) error {
+	backoff := &retryPolicy{baseDelay: baseDelay}
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || !isTransient(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff.delay(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransient reports whether err, or an error it wraps, has a retryable gRPC status code.
+func isTransient(err error) bool {
+	var grpcErr interface {
+		GRPCStatus() *status.Status
+	}
+
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+
+	return defaultRetryableCodes[grpcErr.GRPCStatus().Code()]
+}
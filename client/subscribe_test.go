@@ -0,0 +1,238 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestClient_SubscribeEvents(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	events := test.EventGenerator()
+
+	addresses := test.AddressGenerator()
+
+	address := addresses.New()
+	eventType := fmt.Sprintf("A.%s.Foo.FooEvent", address.Hex())
+
+	opts := []client.SubscribeOption{
+		client.WithSubscriptionPollInterval(time.Millisecond),
+		client.WithReconnectDelay(time.Millisecond),
+	}
+
+	headerResponse := func(height uint64) *access.BlockHeaderResponse {
+		return &access.BlockHeaderResponse{
+			Block: convert.BlockHeaderToMessage(flow.BlockHeader{ID: ids.New(), Height: height}),
+		}
+	}
+
+	newEvent := func() (flow.Event, *entities.Event) {
+		event := events.New()
+		event.Type = eventType
+
+		msg, err := convert.EventToMessage(event)
+		require.NoError(t, err)
+
+		return event, msg
+	}
+
+	unavailable := status.Error(codes.Unavailable, "connection lost")
+
+	t.Run("Reconnect", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(headerResponse(3), nil).Once()
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(nil, unavailable).Once()
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(headerResponse(5), nil)
+
+		blockA := ids.New()
+		blockB := ids.New()
+
+		eventA, eventAMsg := newEvent()
+		eventB, eventBMsg := newEvent()
+
+		rpc.On("GetEventsForHeightRange", mock.Anything, &access.GetEventsForHeightRangeRequest{
+			Type:        eventType,
+			StartHeight: 1,
+			EndHeight:   3,
+		}).Return(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{
+				{BlockId: ids.New().Bytes(), BlockHeight: 1},
+				{BlockId: blockA.Bytes(), BlockHeight: 2, Events: []*entities.Event{eventAMsg}},
+				{BlockId: ids.New().Bytes(), BlockHeight: 3},
+			},
+		}, nil).Once()
+
+		rpc.On("GetEventsForHeightRange", mock.Anything, &access.GetEventsForHeightRangeRequest{
+			Type:        eventType,
+			StartHeight: 4,
+			EndHeight:   5,
+		}).Return(nil, unavailable).Once()
+
+		rpc.On("GetEventsForHeightRange", mock.Anything, &access.GetEventsForHeightRangeRequest{
+			Type:        eventType,
+			StartHeight: 4,
+			EndHeight:   5,
+		}).Return(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{
+				{BlockId: blockB.Bytes(), BlockHeight: 4, Events: []*entities.Event{eventBMsg}},
+				{BlockId: ids.New().Bytes(), BlockHeight: 5},
+			},
+		}, nil).Once()
+
+		c := client.NewFromRPCClient(rpc)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		goroutines := runtime.NumGoroutine()
+
+		batches, errs := c.SubscribeEvents(ctx, client.EventFilter{
+			Types:       []string{eventType},
+			StartHeight: 1,
+		}, opts...)
+
+		batch := <-batches
+		assert.Equal(t, blockA, batch.BlockID)
+		assert.Equal(t, uint64(2), batch.Height)
+		assert.Equal(t, []flow.Event{eventA}, batch.Events)
+
+		batch = <-batches
+		assert.Equal(t, blockB, batch.BlockID)
+		assert.Equal(t, uint64(4), batch.Height)
+		assert.Equal(t, []flow.Event{eventB}, batch.Events)
+
+		cancel()
+
+		for range batches {
+			t.Fatal("unexpected batch")
+		}
+
+		_, ok := <-errs
+		assert.False(t, ok)
+
+		// the polling goroutine exits and makes no further requests
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+
+		calls := len(rpc.Calls)
+		time.Sleep(10 * time.Millisecond)
+		assert.Len(t, rpc.Calls, calls)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Address filter", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		other := addresses.New()
+		otherType := fmt.Sprintf("A.%s.Bar.BarEvent", other.Hex())
+
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(headerResponse(7), nil)
+
+		event, eventMsg := newEvent()
+		blockID := ids.New()
+
+		rpc.On("GetEventsForHeightRange", mock.Anything, &access.GetEventsForHeightRangeRequest{
+			Type:        eventType,
+			StartHeight: 7,
+			EndHeight:   7,
+		}).Return(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{
+				{BlockId: blockID.Bytes(), BlockHeight: 7, Events: []*entities.Event{eventMsg}},
+			},
+		}, nil).Once()
+
+		c := client.NewFromRPCClient(rpc)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		batches, _ := c.SubscribeEvents(ctx, client.EventFilter{
+			Types:     []string{eventType, otherType},
+			Addresses: []flow.Address{address},
+		}, opts...)
+
+		batch := <-batches
+		assert.Equal(t, blockID, batch.BlockID)
+		assert.Equal(t, []flow.Event{event}, batch.Events)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		rpc.On("GetLatestBlockHeader", mock.Anything, mock.Anything).
+			Return(headerResponse(3), nil)
+
+		rpc.On("GetEventsForHeightRange", mock.Anything, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		c := client.NewFromRPCClient(rpc)
+
+		batches, errs := c.SubscribeEvents(context.Background(), client.EventFilter{
+			Types: []string{eventType},
+		}, opts...)
+
+		err := <-errs
+		assert.Error(t, err)
+
+		_, ok := <-batches
+		assert.False(t, ok)
+	})
+
+	t.Run("No event types", func(t *testing.T) {
+		c := client.NewFromRPCClient(&mocks.RPCClient{})
+
+		batches, errs := c.SubscribeEvents(context.Background(), client.EventFilter{}, opts...)
+
+		err := <-errs
+		assert.Error(t, err)
+
+		_, ok := <-batches
+		assert.False(t, ok)
+	})
+}
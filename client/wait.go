@@ -0,0 +1,203 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// defaultPollInterval is the default interval between transaction result requests in the wait helpers.
+const defaultPollInterval = time.Second
+
+// A TransactionObserver receives notifications as a transaction moves through its lifecycle
+// in the submit and wait helpers.
+//
+// Building and signing happen before a transaction is passed to the client,
+// so the first notification is its submission.
+type TransactionObserver interface {
+	// TransactionSubmitted is called after a transaction is sent to the access node,
+	// with the error returned by the node, if any.
+	TransactionSubmitted(tx flow.Transaction, err error)
+	// TransactionStatusChanged is called when a transaction is first observed with a new status.
+	TransactionStatusChanged(txID flow.Identifier, status flow.TransactionStatus)
+	// TransactionSealed is called when a transaction is observed to be sealed.
+	TransactionSealed(txID flow.Identifier, result *flow.TransactionResult)
+}
+
+// A PollStrategy returns the interval to wait before the next transaction result request,
+// given the number of requests made so far.
+type PollStrategy func(attempt int) time.Duration
+
+// ConstantPollStrategy returns a poll strategy that always waits for the same interval.
+func ConstantPollStrategy(interval time.Duration) PollStrategy {
+	return func(attempt int) time.Duration {
+		return interval
+	}
+}
+
+// ExponentialPollStrategy returns a poll strategy that waits for the initial interval after
+// the first request and doubles the interval after each following request, up to max.
+func ExponentialPollStrategy(initial, max time.Duration) PollStrategy {
+	return func(attempt int) time.Duration {
+		interval := initial
+
+		for i := 1; i < attempt && interval < max; i++ {
+			interval *= 2
+		}
+
+		if interval > max {
+			return max
+		}
+
+		return interval
+	}
+}
+
+// newTimerFunc starts a timer, returning its channel and a function that stops it.
+type newTimerFunc func(d time.Duration) (<-chan time.Time, func())
+
+func newTimer(d time.Duration) (<-chan time.Time, func()) {
+	timer := time.NewTimer(d)
+	return timer.C, func() { timer.Stop() }
+}
+
+type waitOptions struct {
+	pollStrategy PollStrategy
+	observer     TransactionObserver
+	newTimer     newTimerFunc
+}
+
+// A WaitOption configures the behaviour of the submit and wait helpers.
+type WaitOption func(*waitOptions)
+
+// WithPollInterval sets a constant interval between transaction result requests.
+//
+// The default interval is one second.
+func WithPollInterval(interval time.Duration) WaitOption {
+	return WithPollStrategy(ConstantPollStrategy(interval))
+}
+
+// WithPollStrategy sets the strategy used to choose the interval between transaction
+// result requests, such as ExponentialPollStrategy.
+//
+// Waiting always ends as soon as the context is done, whatever the interval.
+func WithPollStrategy(strategy PollStrategy) WaitOption {
+	return func(o *waitOptions) {
+		o.pollStrategy = strategy
+	}
+}
+
+// WithTransactionObserver sets an observer to be notified of the lifecycle of the transaction.
+func WithTransactionObserver(observer TransactionObserver) WaitOption {
+	return func(o *waitOptions) {
+		o.observer = observer
+	}
+}
+
+func newWaitOptions(opts []WaitOption) waitOptions {
+	options := waitOptions{
+		pollStrategy: ConstantPollStrategy(defaultPollInterval),
+		newTimer:     newTimer,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// SendAndWaitForSeal submits a transaction to the network and waits for it to be sealed.
+//
+// This function returns an error if the transaction cannot be sent, or if ctx is done
+// before the transaction is sealed. The returned result may contain an execution error.
+func (c *Client) SendAndWaitForSeal(
+	ctx context.Context,
+	tx flow.Transaction,
+	opts ...WaitOption,
+) (*flow.TransactionResult, error) {
+	options := newWaitOptions(opts)
+
+	err := c.SendTransaction(ctx, tx)
+
+	if options.observer != nil {
+		options.observer.TransactionSubmitted(tx, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.waitForSeal(ctx, tx.ID(), options)
+}
+
+// WaitForSeal waits for a transaction to be sealed, polling the access node for its result.
+//
+// This function returns an error if ctx is done before the transaction is sealed.
+// The returned result may contain an execution error.
+func (c *Client) WaitForSeal(
+	ctx context.Context,
+	txID flow.Identifier,
+	opts ...WaitOption,
+) (*flow.TransactionResult, error) {
+	return c.waitForSeal(ctx, txID, newWaitOptions(opts))
+}
+
+func (c *Client) waitForSeal(
+	ctx context.Context,
+	txID flow.Identifier,
+	options waitOptions,
+) (*flow.TransactionResult, error) {
+	lastStatus := flow.TransactionStatusUnknown
+
+	for attempt := 1; ; attempt++ {
+		result, err := c.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Status != lastStatus {
+			lastStatus = result.Status
+
+			if options.observer != nil {
+				options.observer.TransactionStatusChanged(txID, result.Status)
+			}
+		}
+
+		if result.Status == flow.TransactionStatusSealed {
+			if options.observer != nil {
+				options.observer.TransactionSealed(txID, result)
+			}
+
+			return result, nil
+		}
+
+		timer, stop := options.newTimer(options.pollStrategy(attempt))
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return nil, ctx.Err()
+		case <-timer:
+		}
+	}
+}
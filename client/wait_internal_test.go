@@ -0,0 +1,136 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// fakeClock records the duration of each timer it starts.
+//
+// If fire is true, each timer fires immediately; otherwise timers never fire.
+type fakeClock struct {
+	fire      bool
+	durations []time.Duration
+}
+
+func (c *fakeClock) newTimer(d time.Duration) (<-chan time.Time, func()) {
+	c.durations = append(c.durations, d)
+
+	ch := make(chan time.Time, 1)
+	if c.fire {
+		ch <- time.Time{}
+	}
+
+	return ch, func() {}
+}
+
+func withFakeClock(clock *fakeClock) WaitOption {
+	return func(o *waitOptions) {
+		o.newTimer = clock.newTimer
+	}
+}
+
+func transactionResultResponse(t *testing.T, status flow.TransactionStatus) *access.TransactionResultResponse {
+	result := test.TransactionResultGenerator().New()
+	result.Status = status
+
+	response, err := convert.TransactionResultToMessage(result)
+	require.NoError(t, err)
+
+	return response
+}
+
+func TestClient_WaitForSeal_PollStrategy(t *testing.T) {
+	txID := test.IdentifierGenerator().New()
+
+	t.Run("Exponential", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		pending := transactionResultResponse(t, flow.TransactionStatusPending)
+		sealed := transactionResultResponse(t, flow.TransactionStatusSealed)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(pending, nil).Times(5)
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(sealed, nil).Once()
+
+		c := NewFromRPCClient(rpc)
+
+		clock := &fakeClock{fire: true}
+
+		result, err := c.WaitForSeal(
+			ctx,
+			txID,
+			WithPollStrategy(ExponentialPollStrategy(100*time.Millisecond, time.Second)),
+			withFakeClock(clock),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+
+		assert.Equal(t, []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			time.Second,
+		}, clock.durations)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Context deadline", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		pending := transactionResultResponse(t, flow.TransactionStatusPending)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(pending, nil).Once()
+
+		c := NewFromRPCClient(rpc)
+
+		// the timer never fires, so only the deadline can end the wait
+		clock := &fakeClock{}
+
+		_, err := c.WaitForSeal(
+			ctx,
+			txID,
+			WithPollStrategy(ExponentialPollStrategy(time.Hour, 24*time.Hour)),
+			withFakeClock(clock),
+		)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, []time.Duration{time.Hour}, clock.durations)
+
+		rpc.AssertExpectations(t)
+	})
+}
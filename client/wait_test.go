@@ -0,0 +1,196 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/client"
+	"github.com/onflow/flow-go-sdk/client/convert"
+	"github.com/onflow/flow-go-sdk/client/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type recordingObserver struct {
+	calls []string
+}
+
+func (o *recordingObserver) TransactionSubmitted(tx flow.Transaction, err error) {
+	o.calls = append(o.calls, fmt.Sprintf("submitted %v", err))
+}
+
+func (o *recordingObserver) TransactionStatusChanged(txID flow.Identifier, status flow.TransactionStatus) {
+	o.calls = append(o.calls, fmt.Sprintf("status %s", status))
+}
+
+func (o *recordingObserver) TransactionSealed(txID flow.Identifier, result *flow.TransactionResult) {
+	o.calls = append(o.calls, "sealed")
+}
+
+func transactionResultResponse(t *testing.T, status flow.TransactionStatus) *access.TransactionResultResponse {
+	result := test.TransactionResultGenerator().New()
+	result.Status = status
+
+	response, err := convert.TransactionResultToMessage(result)
+	require.NoError(t, err)
+
+	return response
+}
+
+func TestClient_SendAndWaitForSeal(t *testing.T) {
+	t.Run("Lifecycle", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		tx := test.TransactionGenerator().New()
+
+		rpc.On("SendTransaction", ctx, mock.Anything).
+			Return(&access.SendTransactionResponse{Id: tx.ID().Bytes()}, nil)
+
+		statuses := []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusPending,
+			flow.TransactionStatusFinalized,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}
+
+		for _, status := range statuses {
+			rpc.On("GetTransactionResult", ctx, &access.GetTransactionRequest{Id: tx.ID().Bytes()}).
+				Return(transactionResultResponse(t, status), nil).
+				Once()
+		}
+
+		observer := &recordingObserver{}
+
+		c := client.NewFromRPCClient(rpc)
+
+		result, err := c.SendAndWaitForSeal(
+			ctx,
+			*tx,
+			client.WithPollInterval(time.Millisecond),
+			client.WithTransactionObserver(observer),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+
+		assert.Equal(t, []string{
+			"submitted <nil>",
+			"status PENDING",
+			"status FINALIZED",
+			"status EXECUTED",
+			"status SEALED",
+			"sealed",
+		}, observer.calls)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Send error", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("SendTransaction", ctx, mock.Anything).
+			Return(nil, errors.New("rpc error"))
+
+		observer := &recordingObserver{}
+
+		c := client.NewFromRPCClient(rpc)
+
+		result, err := c.SendAndWaitForSeal(
+			ctx,
+			*test.TransactionGenerator().New(),
+			client.WithTransactionObserver(observer),
+		)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		assert.Equal(t, []string{"submitted client: rpc error"}, observer.calls)
+
+		rpc.AssertExpectations(t)
+	})
+
+	t.Run("Without observer", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx := context.Background()
+
+		rpc.On("SendTransaction", ctx, mock.Anything).
+			Return(&access.SendTransactionResponse{}, nil)
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(t, flow.TransactionStatusSealed), nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		result, err := c.SendAndWaitForSeal(ctx, *test.TransactionGenerator().New())
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	})
+}
+
+func TestClient_WaitForSeal(t *testing.T) {
+	t.Run("Context done", func(t *testing.T) {
+		rpc := &mocks.RPCClient{}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		rpc.On("GetTransactionResult", ctx, mock.Anything).
+			Return(transactionResultResponse(t, flow.TransactionStatusPending), nil)
+
+		c := client.NewFromRPCClient(rpc)
+
+		result, err := c.WaitForSeal(ctx, test.IdentifierGenerator().New(), client.WithPollInterval(time.Millisecond))
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestExponentialPollStrategy(t *testing.T) {
+	strategy := client.ExponentialPollStrategy(250*time.Millisecond, 3*time.Second)
+
+	intervals := make([]time.Duration, 6)
+	for i := range intervals {
+		intervals[i] = strategy(i + 1)
+	}
+
+	assert.Equal(t, []time.Duration{
+		250 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		2 * time.Second,
+		3 * time.Second,
+		3 * time.Second,
+	}, intervals)
+}
@@ -0,0 +1,70 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/json"
+)
+
+// A TransactionCodec encodes and decodes transactions to and from a storage format.
+//
+// Decoding the output of Encode must produce a transaction equal to the original.
+// Local metadata attached with SetMetadata is not encoded.
+type TransactionCodec interface {
+	Encode(tx *Transaction) ([]byte, error)
+	Decode(b []byte) (*Transaction, error)
+}
+
+// RLPTransactionCodec is a TransactionCodec that uses the canonical RLP encoding
+// produced by Transaction.Encode.
+type RLPTransactionCodec struct{}
+
+var _ TransactionCodec = RLPTransactionCodec{}
+
+// Encode returns the canonical RLP encoding of the transaction.
+func (RLPTransactionCodec) Encode(tx *Transaction) ([]byte, error) {
+	return tx.Encode(), nil
+}
+
+// Decode decodes a transaction from its canonical RLP encoding.
+func (RLPTransactionCodec) Decode(b []byte) (*Transaction, error) {
+	return DecodeTransaction(b)
+}
+
+// JSONTransactionCodec is a TransactionCodec that encodes transactions as JSON.
+type JSONTransactionCodec struct{}
+
+var _ TransactionCodec = JSONTransactionCodec{}
+
+// Encode returns the JSON encoding of the transaction.
+func (JSONTransactionCodec) Encode(tx *Transaction) ([]byte, error) {
+	return json.Marshal(tx)
+}
+
+// Decode decodes a transaction from its JSON encoding.
+func (JSONTransactionCodec) Decode(b []byte) (*Transaction, error) {
+	var tx Transaction
+
+	err := json.Unmarshal(b, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
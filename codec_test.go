@@ -0,0 +1,60 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestTransactionCodecs(t *testing.T) {
+	codecs := map[string]flow.TransactionCodec{
+		"RLP":  flow.RLPTransactionCodec{},
+		"JSON": flow.JSONTransactionCodec{},
+	}
+
+	for name, codec := range codecs {
+		codec := codec
+
+		t.Run(name, func(t *testing.T) {
+			t.Run("Round trip", func(t *testing.T) {
+				tx := test.TransactionGenerator().New()
+
+				b, err := codec.Encode(tx)
+				require.NoError(t, err)
+
+				decoded, err := codec.Decode(b)
+				require.NoError(t, err)
+
+				assert.Equal(t, tx, decoded)
+				assert.Equal(t, tx.ID(), decoded.ID())
+			})
+
+			t.Run("Malformed", func(t *testing.T) {
+				_, err := codec.Decode([]byte{1, 2, 3})
+				assert.Error(t, err)
+			})
+		})
+	}
+}
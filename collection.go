@@ -18,6 +18,8 @@
 
 package flow
 
+import "fmt"
+
 // A Collection is a list of transactions bundled together for inclusion in a block.
 type Collection struct {
 	TransactionIDs []Identifier
@@ -25,7 +27,7 @@ type Collection struct {
 
 // ID returns the canonical SHA3-256 hash of this collection.
 func (c Collection) ID() Identifier {
-	return HashToID(DefaultHasher.ComputeHash(c.Encode()))
+	return HashToID(canonicalHash(c.Encode()))
 }
 
 // Encode returns the canonical RLP byte representation of this collection.
@@ -43,6 +45,50 @@ func (c Collection) Encode() []byte {
 	return mustRLPEncode(&temp)
 }
 
+// A CollectionProof proves that a transaction is included in a collection.
+//
+// Collection IDs are the hash of the full list of transaction IDs rather than the root
+// of a Merkle tree, so the proof contains the ID of every transaction in the collection
+// and the position of the proven transaction.
+type CollectionProof struct {
+	Index          int
+	TransactionIDs []Identifier
+}
+
+// ProveTransaction returns a proof that the transaction with the given ID is included in
+// this collection.
+func (c Collection) ProveTransaction(txID Identifier) (CollectionProof, error) {
+	for i, id := range c.TransactionIDs {
+		if id == txID {
+			transactionIDs := make([]Identifier, len(c.TransactionIDs))
+			copy(transactionIDs, c.TransactionIDs)
+
+			return CollectionProof{
+				Index:          i,
+				TransactionIDs: transactionIDs,
+			}, nil
+		}
+	}
+
+	return CollectionProof{}, fmt.Errorf("transaction %s is not included in the collection", txID)
+}
+
+// VerifyTransactionInCollection reports whether the proof shows that the transaction with
+// the given ID is included in the collection with the given ID.
+func VerifyTransactionInCollection(txID, collectionID Identifier, proof CollectionProof) bool {
+	if proof.Index < 0 || proof.Index >= len(proof.TransactionIDs) {
+		return false
+	}
+
+	if proof.TransactionIDs[proof.Index] != txID {
+		return false
+	}
+
+	collection := Collection{TransactionIDs: proof.TransactionIDs}
+
+	return collection.ID() == collectionID
+}
+
 // A CollectionGuarantee is an attestation signed by the nodes that have guaranteed a collection.
 type CollectionGuarantee struct {
 	CollectionID Identifier
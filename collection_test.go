@@ -0,0 +1,81 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestCollection_ProveTransaction(t *testing.T) {
+	ids := test.IdentifierGenerator()
+
+	collection := flow.Collection{
+		TransactionIDs: []flow.Identifier{ids.New(), ids.New(), ids.New()},
+	}
+
+	collectionID := collection.ID()
+
+	t.Run("Included", func(t *testing.T) {
+		for i, txID := range collection.TransactionIDs {
+			proof, err := collection.ProveTransaction(txID)
+			require.NoError(t, err)
+
+			assert.Equal(t, i, proof.Index)
+			assert.True(t, flow.VerifyTransactionInCollection(txID, collectionID, proof))
+		}
+	})
+
+	t.Run("Not included", func(t *testing.T) {
+		_, err := collection.ProveTransaction(ids.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong transaction", func(t *testing.T) {
+		proof, err := collection.ProveTransaction(collection.TransactionIDs[0])
+		require.NoError(t, err)
+
+		assert.False(t, flow.VerifyTransactionInCollection(collection.TransactionIDs[1], collectionID, proof))
+	})
+
+	t.Run("Wrong collection", func(t *testing.T) {
+		proof, err := collection.ProveTransaction(collection.TransactionIDs[0])
+		require.NoError(t, err)
+
+		other := flow.Collection{TransactionIDs: collection.TransactionIDs[:2]}
+
+		assert.False(t, flow.VerifyTransactionInCollection(collection.TransactionIDs[0], other.ID(), proof))
+	})
+
+	t.Run("Tampered proof", func(t *testing.T) {
+		proof, err := collection.ProveTransaction(collection.TransactionIDs[0])
+		require.NoError(t, err)
+
+		proof.TransactionIDs[2] = ids.New()
+		assert.False(t, flow.VerifyTransactionInCollection(collection.TransactionIDs[0], collectionID, proof))
+
+		proof.Index = 5
+		assert.False(t, flow.VerifyTransactionInCollection(collection.TransactionIDs[0], collectionID, proof))
+	})
+}
@@ -0,0 +1,102 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// ParseContractImports returns the addresses of the accounts imported by the given Cadence source,
+// in the order they are first imported.
+//
+// Imports by name, such as `import "FungibleToken"`, are ignored. Use ResolveContractImports
+// to include them.
+//
+// This function returns an error if the source cannot be parsed.
+func ParseContractImports(source []byte) ([]Address, error) {
+	return parseContractImports(source, func(name string) (Address, bool, error) {
+		return Address{}, false, nil
+	})
+}
+
+// ResolveContractImports returns the addresses of the accounts imported by the given Cadence source,
+// in the order they are first imported.
+//
+// Imports by name, such as `import "FungibleToken"`, are resolved using the given mapping
+// from names to addresses.
+//
+// This function returns an error if the source cannot be parsed, or if an import name
+// is missing from the mapping.
+func ResolveContractImports(source []byte, names map[string]Address) ([]Address, error) {
+	return parseContractImports(source, func(name string) (Address, bool, error) {
+		address, ok := names[name]
+		if !ok {
+			return Address{}, false, fmt.Errorf("cannot resolve import %q", name)
+		}
+
+		return address, true, nil
+	})
+}
+
+func parseContractImports(
+	source []byte,
+	resolve func(name string) (Address, bool, error),
+) ([]Address, error) {
+	program, _, err := parser.ParseProgram(string(source))
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]Address, 0)
+	seen := make(map[Address]struct{})
+
+	for _, declaration := range program.ImportDeclarations() {
+		var address Address
+
+		switch location := declaration.Location.(type) {
+		case ast.AddressLocation:
+			address = BytesToAddress(location)
+		case ast.StringLocation:
+			resolved, ok, err := resolve(string(location))
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			address = resolved
+		default:
+			continue
+		}
+
+		if _, ok := seen[address]; ok {
+			continue
+		}
+
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const contractWithImports = `
+import FungibleToken from 0xee82856bf20e2aa6
+import FlowToken from 0x0ae53cb6e3f42a79
+import FlowFees from 0xe5a8b7f23e8b548f
+import FungibleTokenMetadata from 0xee82856bf20e2aa6
+import "NonFungibleToken"
+
+pub contract Marketplace {}
+`
+
+func TestParseContractImports(t *testing.T) {
+	t.Run("Multiple imports", func(t *testing.T) {
+		addresses, err := flow.ParseContractImports([]byte(contractWithImports))
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.Address{
+			flow.HexToAddress("ee82856bf20e2aa6"),
+			flow.HexToAddress("0ae53cb6e3f42a79"),
+			flow.HexToAddress("e5a8b7f23e8b548f"),
+		}, addresses)
+	})
+
+	t.Run("No imports", func(t *testing.T) {
+		addresses, err := flow.ParseContractImports([]byte("pub contract Empty {}"))
+		require.NoError(t, err)
+
+		assert.Empty(t, addresses)
+	})
+
+	t.Run("Malformed source", func(t *testing.T) {
+		_, err := flow.ParseContractImports([]byte("import FungibleToken from"))
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveContractImports(t *testing.T) {
+	t.Run("Resolved", func(t *testing.T) {
+		addresses, err := flow.ResolveContractImports(
+			[]byte(contractWithImports),
+			map[string]flow.Address{
+				"NonFungibleToken": flow.HexToAddress("1d7e57aa55817448"),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.Address{
+			flow.HexToAddress("ee82856bf20e2aa6"),
+			flow.HexToAddress("0ae53cb6e3f42a79"),
+			flow.HexToAddress("e5a8b7f23e8b548f"),
+			flow.HexToAddress("1d7e57aa55817448"),
+		}, addresses)
+	})
+
+	t.Run("Unresolved", func(t *testing.T) {
+		_, err := flow.ResolveContractImports([]byte(contractWithImports), nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,172 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package awskms provides a crypto.Signer backed by an AWS KMS key.
+package awskms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/internal/der"
+)
+
+// Key specs of the AWS KMS asymmetric keys supported by Flow.
+const (
+	KeySpecECCNISTP256   = "ECC_NIST_P256"
+	KeySpecECCSECGP256K1 = "ECC_SECG_P256K1"
+)
+
+// SigningAlgorithmECDSASHA256 is the AWS KMS signing algorithm for ECDSA with a SHA-256 digest.
+const SigningAlgorithmECDSASHA256 = "ECDSA_SHA_256"
+
+// signatureSize is the size in bytes of each of the r and s values of a signature.
+const signatureSize = 32
+
+// A Client is an AWS KMS client.
+//
+// It is a small adapter over the Sign and GetPublicKey operations of the AWS SDK,
+// so that this package does not depend on a particular version of the SDK.
+type Client interface {
+	// Sign calls the KMS Sign operation with a message type of DIGEST and returns the
+	// DER-encoded signature.
+	Sign(ctx context.Context, keyID string, digest []byte, signingAlgorithm string) ([]byte, error)
+	// GetPublicKey calls the KMS GetPublicKey operation and returns the DER-encoded
+	// public key and the key spec of the key.
+	GetPublicKey(ctx context.Context, keyID string) (publicKey []byte, keySpec string, err error)
+}
+
+// A Signer is a crypto.Signer that signs messages with an asymmetric AWS KMS key.
+//
+// Messages are hashed locally, and only the digest is sent to KMS.
+type Signer struct {
+	client    Client
+	keyID     string
+	hashAlgo  crypto.HashAlgorithm
+	publicKey crypto.PublicKey
+}
+
+// NewSigner returns a signer for the KMS key with the given ID or ARN.
+//
+// The public key and key spec are fetched from KMS. This function returns an error if
+// the signature and hash algorithms are not supported by KMS, or if the key spec
+// does not match the signature algorithm.
+//
+// ECDSA_P256 and ECDSA_secp256k1 keys are supported, with the SHA2_256 hash algorithm.
+func NewSigner(
+	ctx context.Context,
+	client Client,
+	keyID string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*Signer, error) {
+	expectedKeySpec, err := keySpec(sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedPublicKey, actualKeySpec, err := client.GetPublicKey(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("awskms: failed to get public key: %w", err)
+	}
+
+	if actualKeySpec != expectedKeySpec {
+		return nil, fmt.Errorf(
+			"awskms: key %s has key spec %s, expected %s",
+			keyID,
+			actualKeySpec,
+			expectedKeySpec,
+		)
+	}
+
+	publicKey, err := DecodePublicKey(sigAlgo, encodedPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		client:    client,
+		keyID:     keyID,
+		hashAlgo:  hashAlgo,
+		publicKey: publicKey,
+	}, nil
+}
+
+// PublicKey returns the public key of the KMS key.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs the given message with the KMS key.
+//
+// KMS returns a DER-encoded signature, which is converted to the raw r || s form expected by Flow.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return s.SignContext(context.Background(), message)
+}
+
+// SignContext signs the given message with the KMS key, using ctx for the KMS request.
+func (s *Signer) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("awskms: %w", err)
+	}
+
+	digest := hasher.ComputeHash(message)
+
+	encoded, err := s.client.Sign(ctx, s.keyID, digest, SigningAlgorithmECDSASHA256)
+	if err != nil {
+		return nil, fmt.Errorf("awskms: failed to sign: %w", err)
+	}
+
+	sig, err := der.ParseSignature(encoded, signatureSize)
+	if err != nil {
+		return nil, fmt.Errorf("awskms: %w", err)
+	}
+
+	return sig, nil
+}
+
+// DecodePublicKey decodes a DER-encoded public key returned by the KMS GetPublicKey operation.
+func DecodePublicKey(sigAlgo crypto.SignatureAlgorithm, encoded []byte) (crypto.PublicKey, error) {
+	raw, err := der.ParsePublicKey(encoded)
+	if err != nil {
+		return crypto.PublicKey{}, fmt.Errorf("awskms: %w", err)
+	}
+
+	publicKey, err := crypto.DecodePublicKey(sigAlgo, raw)
+	if err != nil {
+		return crypto.PublicKey{}, fmt.Errorf("awskms: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// keySpec returns the KMS key spec for a signature and hash algorithm pair.
+func keySpec(sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm) (string, error) {
+	if hashAlgo == crypto.SHA2_256 {
+		switch sigAlgo {
+		case crypto.ECDSA_P256:
+			return KeySpecECCNISTP256, nil
+		case crypto.ECDSA_secp256k1:
+			return KeySpecECCSECGP256K1, nil
+		}
+	}
+
+	return "", fmt.Errorf("awskms: unsupported algorithms %s and %s", sigAlgo, hashAlgo)
+}
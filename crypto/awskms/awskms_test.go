@@ -0,0 +1,171 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package awskms_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/awskms"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+const keyID = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+// mockKMS is a KMS client that signs with an in-memory ECDSA key.
+type mockKMS struct {
+	key       *ecdsa.PrivateKey
+	publicKey []byte
+	keySpec   string
+	signErr   error
+}
+
+func newMockKMS(t *testing.T, sigAlgo crypto.SignatureAlgorithm) *mockKMS {
+	switch sigAlgo {
+	case crypto.ECDSA_P256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		return &mockKMS{key: key, publicKey: publicKey, keySpec: awskms.KeySpecECCNISTP256}
+	case crypto.ECDSA_secp256k1:
+		key, err := ethcrypto.GenerateKey()
+		require.NoError(t, err)
+
+		// the standard library cannot marshal secp256k1 keys
+		publicKey, err := asn1.Marshal(struct {
+			Algorithm struct {
+				Algorithm  asn1.ObjectIdentifier
+				Parameters asn1.ObjectIdentifier
+			}
+			PublicKey asn1.BitString
+		}{
+			Algorithm: struct {
+				Algorithm  asn1.ObjectIdentifier
+				Parameters asn1.ObjectIdentifier
+			}{
+				Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+				Parameters: asn1.ObjectIdentifier{1, 3, 132, 0, 10},
+			},
+			PublicKey: asn1.BitString{
+				Bytes:     ethcrypto.FromECDSAPub(&key.PublicKey),
+				BitLength: 65 * 8,
+			},
+		})
+		require.NoError(t, err)
+
+		return &mockKMS{key: key, publicKey: publicKey, keySpec: awskms.KeySpecECCSECGP256K1}
+	}
+
+	t.Fatalf("unsupported signature algorithm %s", sigAlgo)
+	return nil
+}
+
+func (m *mockKMS) Sign(_ context.Context, _ string, digest []byte, signingAlgorithm string) ([]byte, error) {
+	if m.signErr != nil {
+		return nil, m.signErr
+	}
+
+	if signingAlgorithm != awskms.SigningAlgorithmECDSASHA256 {
+		return nil, errors.New("unsupported signing algorithm")
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, m.key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func (m *mockKMS) GetPublicKey(_ context.Context, _ string) ([]byte, string, error) {
+	return m.publicKey, m.keySpec, nil
+}
+
+func TestSigner(t *testing.T) {
+	ctx := context.Background()
+
+	hasher, err := crypto.NewHasher(crypto.SHA2_256)
+	require.NoError(t, err)
+
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		sigAlgo := sigAlgo
+
+		t.Run(sigAlgo.String(), func(t *testing.T) {
+			kms := newMockKMS(t, sigAlgo)
+
+			signer, err := awskms.NewSigner(ctx, kms, keyID, sigAlgo, crypto.SHA2_256)
+			require.NoError(t, err)
+
+			tx := test.TransactionGenerator().New()
+			tx.EnvelopeSignatures = nil
+
+			err = tx.SignEnvelope(tx.Payer, 0, signer)
+			require.NoError(t, err)
+			require.Len(t, tx.EnvelopeSignatures, 1)
+
+			sig := tx.EnvelopeSignatures[0].Signature
+			assert.Len(t, sig, 64)
+
+			valid, err := signer.PublicKey().Verify(sig, tx.EnvelopeMessage(), hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+
+	t.Run("Key spec mismatch", func(t *testing.T) {
+		kms := newMockKMS(t, crypto.ECDSA_P256)
+
+		_, err := awskms.NewSigner(ctx, kms, keyID, crypto.ECDSA_secp256k1, crypto.SHA2_256)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsupported algorithms", func(t *testing.T) {
+		kms := newMockKMS(t, crypto.ECDSA_P256)
+
+		_, err := awskms.NewSigner(ctx, kms, keyID, crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.Error(t, err)
+	})
+
+	t.Run("Sign error", func(t *testing.T) {
+		kms := newMockKMS(t, crypto.ECDSA_P256)
+
+		signer, err := awskms.NewSigner(ctx, kms, keyID, crypto.ECDSA_P256, crypto.SHA2_256)
+		require.NoError(t, err)
+
+		kms.signErr = errors.New("throttled")
+
+		_, err = signer.Sign([]byte("message"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,125 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// A VerifyEntry is a single signature to be verified by VerifyBatch.
+type VerifyEntry struct {
+	PublicKey PublicKey
+	Signature []byte
+	Message   []byte
+	Hasher    Hasher
+}
+
+// VerifyBatch verifies a batch of signatures and returns the result for each entry, in order.
+//
+// A false result means the signature is not valid for the message, or that it could not be
+// checked. An error means that verification could not be performed for at least one entry,
+// for example because an entry is missing its public key or hasher; the error describes the
+// first such entry, and the results of all other entries are still returned.
+//
+// Entries are verified concurrently, and may share a hasher. Each signature is verified
+// individually. BLS keys are not supported by this package, so BLS aggregate verification
+// is not available.
+func VerifyBatch(entries []VerifyEntry) ([]bool, error) {
+	results := make([]bool, len(entries))
+	errs := make([]error, len(entries))
+
+	hashers := make(map[Hasher]*lockedHasher)
+	for _, entry := range entries {
+		if entry.Hasher != nil && hashers[entry.Hasher] == nil {
+			hashers[entry.Hasher] = &lockedHasher{Hasher: entry.Hasher}
+		}
+	}
+
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	var wg sync.WaitGroup
+
+	for i, entry := range entries {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, entry VerifyEntry) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			results[i], errs[i] = verifyEntry(entry, hashers[entry.Hasher])
+		}(i, entry)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return results, fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+
+	return results, nil
+}
+
+func verifyEntry(entry VerifyEntry, hasher *lockedHasher) (bool, error) {
+	if entry.PublicKey.publicKey == nil {
+		return false, fmt.Errorf("missing public key")
+	}
+
+	if hasher == nil {
+		return false, fmt.Errorf("missing hasher")
+	}
+
+	return entry.PublicKey.Verify(entry.Signature, entry.Message, hasher)
+}
+
+// lockedHasher serializes the use of a hasher shared by several batch entries.
+type lockedHasher struct {
+	Hasher
+	mu sync.Mutex
+}
+
+func (h *lockedHasher) ComputeHash(data []byte) Hash {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.Hasher.ComputeHash(data)
+}
+
+func (h *lockedHasher) Write(p []byte) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.Hasher.Write(p)
+}
+
+func (h *lockedHasher) SumHash() Hash {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.Hasher.SumHash()
+}
+
+func (h *lockedHasher) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Hasher.Reset()
+}
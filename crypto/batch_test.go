@@ -0,0 +1,107 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	seed := []byte("elephant ears space cowboy octopus rodeo potato cannon pineapple")
+
+	privateKeyA, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+
+	privateKeyB, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, seed)
+	require.NoError(t, err)
+
+	messageA := []byte("message A")
+	messageB := []byte("message B")
+
+	sigA, err := privateKeyA.Sign(messageA, crypto.NewSHA3_256())
+	require.NoError(t, err)
+
+	sigB, err := privateKeyB.Sign(messageB, crypto.NewSHA2_256())
+	require.NoError(t, err)
+
+	t.Run("Mixed results", func(t *testing.T) {
+		results, err := crypto.VerifyBatch([]crypto.VerifyEntry{
+			{PublicKey: privateKeyA.PublicKey(), Signature: sigA, Message: messageA, Hasher: crypto.NewSHA3_256()},
+			{PublicKey: privateKeyB.PublicKey(), Signature: sigB, Message: messageB, Hasher: crypto.NewSHA2_256()},
+			{PublicKey: privateKeyA.PublicKey(), Signature: sigA, Message: messageB, Hasher: crypto.NewSHA3_256()},
+			{PublicKey: privateKeyB.PublicKey(), Signature: sigA, Message: messageA, Hasher: crypto.NewSHA2_256()},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []bool{true, true, false, false}, results)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		results, err := crypto.VerifyBatch(nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Shared hasher", func(t *testing.T) {
+		hasher := crypto.NewSHA3_256()
+
+		entries := make([]crypto.VerifyEntry, 100)
+		for i := range entries {
+			entries[i] = crypto.VerifyEntry{
+				PublicKey: privateKeyA.PublicKey(),
+				Signature: sigA,
+				Message:   messageA,
+				Hasher:    hasher,
+			}
+		}
+
+		results, err := crypto.VerifyBatch(entries)
+		require.NoError(t, err)
+
+		for _, valid := range results {
+			assert.True(t, valid)
+		}
+	})
+
+	t.Run("Missing public key", func(t *testing.T) {
+		results, err := crypto.VerifyBatch([]crypto.VerifyEntry{
+			{PublicKey: privateKeyA.PublicKey(), Signature: sigA, Message: messageA, Hasher: crypto.NewSHA3_256()},
+			{Signature: sigA, Message: messageA, Hasher: crypto.NewSHA3_256()},
+			{PublicKey: privateKeyB.PublicKey(), Signature: sigB, Message: messageB, Hasher: crypto.NewSHA2_256()},
+		})
+		assert.Error(t, err)
+
+		assert.Equal(t, []bool{true, false, true}, results)
+	})
+
+	t.Run("Missing hasher", func(t *testing.T) {
+		results, err := crypto.VerifyBatch([]crypto.VerifyEntry{
+			{PublicKey: privateKeyA.PublicKey(), Signature: sigA, Message: messageA},
+			{PublicKey: privateKeyA.PublicKey(), Signature: sigA, Message: messageA, Hasher: crypto.NewSHA3_256()},
+		})
+		assert.Error(t, err)
+
+		assert.Equal(t, []bool{false, true}, results)
+	})
+}
@@ -0,0 +1,185 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cloudkms provides a crypto.Signer backed by a Google Cloud KMS key.
+package cloudkms
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/internal/der"
+)
+
+// ecSignSecp256k1SHA256 is the KMS algorithm for ECDSA on the secp256k1 curve with a SHA256 digest.
+//
+// It is defined here because it is missing from the generated KMS protobuf package.
+const ecSignSecp256k1SHA256 kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm = 31
+
+// signatureSize is the size in bytes of each of the r and s values of a signature.
+const signatureSize = 32
+
+// A Client is a Google Cloud KMS client.
+//
+// It is implemented by the client returned by kmspb.NewKeyManagementServiceClient.
+type Client interface {
+	AsymmetricSign(
+		ctx context.Context,
+		req *kmspb.AsymmetricSignRequest,
+		opts ...grpc.CallOption,
+	) (*kmspb.AsymmetricSignResponse, error)
+	GetPublicKey(
+		ctx context.Context,
+		req *kmspb.GetPublicKeyRequest,
+		opts ...grpc.CallOption,
+	) (*kmspb.PublicKey, error)
+}
+
+// A Signer is a crypto.Signer that signs messages with an asymmetric Google Cloud KMS key.
+//
+// Messages are hashed locally, and only the digest is sent to KMS.
+type Signer struct {
+	client    Client
+	keyName   string
+	hashAlgo  crypto.HashAlgorithm
+	publicKey crypto.PublicKey
+}
+
+// NewSigner returns a signer for the KMS crypto key version with the given resource name,
+// in the form projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+//
+// The public key of the key version is fetched from KMS. This function returns an error
+// if the signature and hash algorithms are not supported by KMS, or if the algorithm
+// of the key version does not match them.
+//
+// ECDSA_P256 and ECDSA_secp256k1 keys are supported, with the SHA2_256 hash algorithm.
+func NewSigner(
+	ctx context.Context,
+	client Client,
+	keyName string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*Signer, error) {
+	algorithm, err := kmsAlgorithm(sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: keyName})
+	if err != nil {
+		return nil, fmt.Errorf("cloudkms: failed to get public key: %w", err)
+	}
+
+	if res.GetAlgorithm() != algorithm {
+		return nil, fmt.Errorf(
+			"cloudkms: key %s has algorithm %s, expected %s",
+			keyName,
+			res.GetAlgorithm(),
+			algorithm,
+		)
+	}
+
+	publicKey, err := decodePublicKey(sigAlgo, res.GetPem())
+	if err != nil {
+		return nil, fmt.Errorf("cloudkms: %w", err)
+	}
+
+	return &Signer{
+		client:    client,
+		keyName:   keyName,
+		hashAlgo:  hashAlgo,
+		publicKey: publicKey,
+	}, nil
+}
+
+// PublicKey returns the public key of the KMS key version.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs the given message with the KMS key version.
+//
+// The returned signature is in the raw r || s form expected by Flow.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return s.SignContext(context.Background(), message)
+}
+
+// SignContext signs the given message with the KMS key version, using ctx for the KMS request.
+func (s *Signer) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("cloudkms: %w", err)
+	}
+
+	digest := hasher.ComputeHash(message)
+
+	res, err := s.client.AsymmetricSign(ctx, &kmspb.AsymmetricSignRequest{
+		Name: s.keyName,
+		Digest: &kmspb.Digest{
+			Digest: &kmspb.Digest_Sha256{Sha256: digest},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cloudkms: failed to sign: %w", err)
+	}
+
+	sig, err := der.ParseSignature(res.GetSignature(), signatureSize)
+	if err != nil {
+		return nil, fmt.Errorf("cloudkms: %w", err)
+	}
+
+	return sig, nil
+}
+
+// kmsAlgorithm returns the KMS algorithm for a signature and hash algorithm pair.
+func kmsAlgorithm(
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm, error) {
+	if hashAlgo == crypto.SHA2_256 {
+		switch sigAlgo {
+		case crypto.ECDSA_P256:
+			return kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256, nil
+		case crypto.ECDSA_secp256k1:
+			return ecSignSecp256k1SHA256, nil
+		}
+	}
+
+	return 0, fmt.Errorf("cloudkms: unsupported algorithms %s and %s", sigAlgo, hashAlgo)
+}
+
+// decodePublicKey decodes a PEM-encoded public key returned by KMS.
+func decodePublicKey(sigAlgo crypto.SignatureAlgorithm, encoded string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil {
+		return crypto.PublicKey{}, errors.New("invalid PEM public key")
+	}
+
+	raw, err := der.ParsePublicKey(block.Bytes)
+	if err != nil {
+		return crypto.PublicKey{}, err
+	}
+
+	return crypto.DecodePublicKey(sigAlgo, raw)
+}
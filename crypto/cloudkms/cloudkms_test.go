@@ -0,0 +1,136 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudkms_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cloudkms"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+const keyName = "projects/flow/locations/global/keyRings/accounts/cryptoKeys/service/cryptoKeyVersions/1"
+
+// fakeKMS is a KMS client that signs with an in-memory ECDSA P-256 key.
+type fakeKMS struct {
+	key       *ecdsa.PrivateKey
+	algorithm kmspb.CryptoKeyVersion_CryptoKeyVersionAlgorithm
+	requests  []*kmspb.AsymmetricSignRequest
+}
+
+func newFakeKMS(t *testing.T) *fakeKMS {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return &fakeKMS{
+		key:       key,
+		algorithm: kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
+	}
+}
+
+func (f *fakeKMS) AsymmetricSign(
+	_ context.Context,
+	req *kmspb.AsymmetricSignRequest,
+	_ ...grpc.CallOption,
+) (*kmspb.AsymmetricSignResponse, error) {
+	f.requests = append(f.requests, req)
+
+	r, s, err := ecdsa.Sign(rand.Reader, f.key, req.GetDigest().GetSha256())
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmspb.AsymmetricSignResponse{Signature: sig}, nil
+}
+
+func (f *fakeKMS) GetPublicKey(
+	_ context.Context,
+	req *kmspb.GetPublicKeyRequest,
+	_ ...grpc.CallOption,
+) (*kmspb.PublicKey, error) {
+	encoded, err := x509.MarshalPKIXPublicKey(&f.key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmspb.PublicKey{
+		Pem:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded})),
+		Algorithm: f.algorithm,
+	}, nil
+}
+
+func TestSigner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sign transaction", func(t *testing.T) {
+		kms := newFakeKMS(t)
+
+		signer, err := cloudkms.NewSigner(ctx, kms, keyName, crypto.ECDSA_P256, crypto.SHA2_256)
+		require.NoError(t, err)
+
+		tx := test.TransactionGenerator().New()
+		tx.EnvelopeSignatures = nil
+
+		err = tx.SignEnvelope(tx.Payer, 0, signer)
+		require.NoError(t, err)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+
+		require.Len(t, kms.requests, 1)
+		assert.Equal(t, keyName, kms.requests[0].GetName())
+
+		hasher, err := crypto.NewHasher(crypto.SHA2_256)
+		require.NoError(t, err)
+
+		valid, err := signer.PublicKey().Verify(tx.EnvelopeSignatures[0].Signature, tx.EnvelopeMessage(), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Unsupported algorithms", func(t *testing.T) {
+		_, err := cloudkms.NewSigner(ctx, newFakeKMS(t), keyName, crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.Error(t, err)
+
+		_, err = cloudkms.NewSigner(ctx, newFakeKMS(t), keyName, crypto.BLS_BLS12381, crypto.SHA2_256)
+		assert.Error(t, err)
+	})
+
+	t.Run("Key algorithm mismatch", func(t *testing.T) {
+		_, err := cloudkms.NewSigner(ctx, newFakeKMS(t), keyName, crypto.ECDSA_secp256k1, crypto.SHA2_256)
+		assert.Error(t, err)
+	})
+}
@@ -19,7 +19,12 @@
 package crypto
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
+	"fmt"
 
 	"github.com/onflow/flow-go-sdk/crypto/internal/crypto"
 )
@@ -104,6 +109,33 @@ const (
 	ECDSA_secp256k1_SHA3_256
 )
 
+// String returns the string representation of this key type.
+func (k KeyType) String() string {
+	return [...]string{
+		"UNKNOWN",
+		"ECDSA_P256_SHA2_256",
+		"ECDSA_P256_SHA3_256",
+		"ECDSA_secp256k1_SHA2_256",
+		"ECDSA_secp256k1_SHA3_256",
+	}[k]
+}
+
+// StringToKeyType converts a string to a KeyType.
+func StringToKeyType(s string) KeyType {
+	switch s {
+	case ECDSA_P256_SHA2_256.String():
+		return ECDSA_P256_SHA2_256
+	case ECDSA_P256_SHA3_256.String():
+		return ECDSA_P256_SHA3_256
+	case ECDSA_secp256k1_SHA2_256.String():
+		return ECDSA_secp256k1_SHA2_256
+	case ECDSA_secp256k1_SHA3_256.String():
+		return ECDSA_secp256k1_SHA3_256
+	default:
+		return UnknownKeyType
+	}
+}
+
 // SignatureAlgorithm returns the signature algorithm for this key type.
 func (k KeyType) SignatureAlgorithm() SignatureAlgorithm {
 	switch k {
@@ -155,6 +187,32 @@ func (sk PrivateKey) Encode() []byte {
 	return sk.privateKey.Encode()
 }
 
+// Equal returns true if this private key and other have the same signature algorithm
+// and encoding.
+//
+// The encodings are compared in constant time.
+func (sk PrivateKey) Equal(other PrivateKey) bool {
+	if sk.privateKey == nil || other.privateKey == nil {
+		return sk.privateKey == nil && other.privateKey == nil
+	}
+
+	if sk.Algorithm() != other.Algorithm() {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(sk.Encode(), other.Encode()) == 1
+}
+
+// MatchesPublicKey returns true if the public key derived from this private key has the
+// same signature algorithm and encoding as pub.
+func (sk PrivateKey) MatchesPublicKey(pub PublicKey) bool {
+	if sk.privateKey == nil {
+		return false
+	}
+
+	return sk.PublicKey().Equal(pub)
+}
+
 // A PublicKey is a cryptographic public key that can be used to verify signatures.
 type PublicKey struct {
 	publicKey crypto.PublicKey
@@ -178,12 +236,59 @@ func (pk PublicKey) Encode() []byte {
 	return pk.publicKey.Encode()
 }
 
+// Equal returns true if this public key and other have the same signature algorithm
+// and encoding.
+func (pk PublicKey) Equal(other PublicKey) bool {
+	if pk.publicKey == nil || other.publicKey == nil {
+		return pk.publicKey == nil && other.publicKey == nil
+	}
+
+	if pk.Algorithm() != other.Algorithm() {
+		return false
+	}
+
+	return bytes.Equal(pk.Encode(), other.Encode())
+}
+
 // A Signer is capable of signing cryptographic messages.
 type Signer interface {
 	// Sign signs the given message with this signer.
 	Sign(message []byte) ([]byte, error)
 }
 
+// A SignerContext is a signer that accepts a context, such as a signer backed by a network service.
+//
+// The method is named SignContext so that a type can implement both Signer and SignerContext.
+// Transaction signing helpers that take a context pass it to signers that implement this interface.
+type SignerContext interface {
+	// SignContext signs the given message, honoring the cancellation and deadline of ctx.
+	SignContext(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// NewSignerContext returns a SignerContext for the given signer.
+//
+// If the signer implements SignerContext it is returned as is. Otherwise the returned signer
+// checks the context before calling Sign, but cannot interrupt a Sign call in progress.
+func NewSignerContext(signer Signer) SignerContext {
+	if s, ok := signer.(SignerContext); ok {
+		return s
+	}
+
+	return signerContextAdapter{signer}
+}
+
+type signerContextAdapter struct {
+	signer Signer
+}
+
+func (s signerContextAdapter) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.signer.Sign(message)
+}
+
 // An InMemorySigner is a signer that generates signatures using an in-memory private key.
 type InMemorySigner struct {
 	PrivateKey PrivateKey
@@ -213,6 +318,59 @@ func NewNaiveSigner(privateKey PrivateKey, hashAlgo HashAlgorithm) NaiveSigner {
 	return NewInMemorySigner(privateKey, hashAlgo)
 }
 
+// A CallbackSigner is a signer that delegates signing to an external function,
+// such as one that prompts a hardware wallet for a signature.
+//
+// The function receives the message exactly as it is passed to Sign, before hashing
+// (e.g. the result of Transaction.PayloadMessage or Transaction.EnvelopeMessage).
+// It is responsible for hashing the message with the hash algorithm of the signing key.
+type CallbackSigner func(message []byte) ([]byte, error)
+
+// Sign signs the given message by passing it to the callback function.
+func (f CallbackSigner) Sign(message []byte) ([]byte, error) {
+	return f(message)
+}
+
+// A DeterministicSigner is a signer that produces the same signature every time it signs
+// the same message.
+//
+// Nonces are derived from the private key and the message hash as defined in RFC 6979,
+// which makes signatures and transaction IDs reproducible in tests.
+type DeterministicSigner struct {
+	PrivateKey PrivateKey
+	Hasher     Hasher
+}
+
+// NewDeterministicSigner initializes and returns a new deterministic signer with a private key
+// generated from the given seed.
+//
+// Signers created with the same seed, signature algorithm and hash algorithm produce
+// identical signatures.
+func NewDeterministicSigner(
+	seed []byte,
+	sigAlgo SignatureAlgorithm,
+	hashAlgo HashAlgorithm,
+) (DeterministicSigner, error) {
+	privateKey, err := GeneratePrivateKey(sigAlgo, seed)
+	if err != nil {
+		return DeterministicSigner{}, err
+	}
+
+	hasher, err := NewHasher(hashAlgo)
+	if err != nil {
+		return DeterministicSigner{}, err
+	}
+
+	return DeterministicSigner{
+		PrivateKey: privateKey,
+		Hasher:     hasher,
+	}, nil
+}
+
+func (s DeterministicSigner) Sign(message []byte) ([]byte, error) {
+	return crypto.SignDeterministic(s.PrivateKey.privateKey, message, s.Hasher)
+}
+
 // GeneratePrivateKey generates a private key with the specified signature algorithm from the given seed.
 func GeneratePrivateKey(sigAlgo SignatureAlgorithm, seed []byte) (PrivateKey, error) {
 	privKey, err := crypto.GeneratePrivateKey(crypto.SigningAlgorithm(sigAlgo), seed)
@@ -225,6 +383,37 @@ func GeneratePrivateKey(sigAlgo SignatureAlgorithm, seed []byte) (PrivateKey, er
 	}, nil
 }
 
+// GeneratePrivateKeyIterated generates a private key with the specified signature algorithm
+// from a seed that is derived from the given seed in the given number of rounds.
+//
+// The first round uses the seed as is, so one round is equivalent to GeneratePrivateKey. Each
+// following round replaces the seed with its SHA3-384 hash, which is as long as the minimum
+// seed length of the supported signature algorithms.
+//
+// This function returns an error if rounds is zero or if the seed is too short.
+func GeneratePrivateKeyIterated(sigAlgo SignatureAlgorithm, seed []byte, rounds uint) (PrivateKey, error) {
+	if rounds == 0 {
+		return PrivateKey{}, errors.New("number of seed derivation rounds must be at least 1")
+	}
+
+	switch sigAlgo {
+	case ECDSA_P256:
+		if len(seed) < MinSeedLengthECDSA_P256 {
+			return PrivateKey{}, fmt.Errorf("seed should be at least %d bytes", MinSeedLengthECDSA_P256)
+		}
+	case ECDSA_secp256k1:
+		if len(seed) < MinSeedLengthECDSA_secp256k1 {
+			return PrivateKey{}, fmt.Errorf("seed should be at least %d bytes", MinSeedLengthECDSA_secp256k1)
+		}
+	}
+
+	for i := uint(1); i < rounds; i++ {
+		seed = NewSHA3_384().ComputeHash(seed)
+	}
+
+	return GeneratePrivateKey(sigAlgo, seed)
+}
+
 // DecodePrivateKey decodes a raw byte encoded private key with the given signature algorithm.
 func DecodePrivateKey(sigAlgo SignatureAlgorithm, b []byte) (PrivateKey, error) {
 	privKey, err := crypto.DecodePrivateKey(crypto.SigningAlgorithm(sigAlgo), b)
@@ -248,6 +437,10 @@ func DecodePrivateKeyHex(sigAlgo SignatureAlgorithm, s string) (PrivateKey, erro
 }
 
 // DecodePublicKey decodes a raw byte encoded public key with the given signature algorithm.
+//
+// ECDSA public keys can be encoded either as the uncompressed coordinates x || y, or in
+// the compressed form prefix || x, where the prefix is 0x02 if y is even and 0x03 if y is odd.
+// An error is returned if the key is not a point on the curve.
 func DecodePublicKey(sigAlgo SignatureAlgorithm, b []byte) (PublicKey, error) {
 	pubKey, err := crypto.DecodePublicKey(crypto.SigningAlgorithm(sigAlgo), b)
 	if err != nil {
@@ -0,0 +1,376 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestCallbackSigner(t *testing.T) {
+	privateKey, err := crypto.GeneratePrivateKey(
+		crypto.ECDSA_P256,
+		[]byte("elephant ears space cowboy octopus rodeo potato cannon pineapple"),
+	)
+	require.NoError(t, err)
+
+	hasher, err := crypto.NewHasher(crypto.SHA3_256)
+	require.NoError(t, err)
+
+	t.Run("Message", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		var received []byte
+
+		signer := crypto.CallbackSigner(func(message []byte) ([]byte, error) {
+			received = message
+			return privateKey.Sign(message, hasher)
+		})
+
+		address := tx.ProposalKey.Address
+		keyID := tx.ProposalKey.KeyID
+
+		err := tx.SignPayload(address, keyID, signer)
+		require.NoError(t, err)
+
+		assert.Equal(t, tx.PayloadMessage(), received)
+
+		sig := tx.PayloadSignatures[len(tx.PayloadSignatures)-1].Signature
+
+		valid, err := privateKey.PublicKey().Verify(sig, tx.PayloadMessage(), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		rejected := errors.New("rejected on device")
+
+		signer := crypto.CallbackSigner(func(message []byte) ([]byte, error) {
+			return nil, rejected
+		})
+
+		tx := flow.NewTransaction().SetPayer(flow.RootAddress)
+
+		err := tx.SignEnvelope(flow.RootAddress, 0, signer)
+		assert.Equal(t, rejected, err)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+}
+
+type contextSigner struct {
+	crypto.Signer
+}
+
+func (s *contextSigner) SignContext(_ context.Context, message []byte) ([]byte, error) {
+	return s.Sign(message)
+}
+
+func TestNewSignerContext(t *testing.T) {
+	privateKey, err := crypto.GeneratePrivateKey(
+		crypto.ECDSA_P256,
+		[]byte("elephant ears space cowboy octopus rodeo potato cannon pineapple"),
+	)
+	require.NoError(t, err)
+
+	signer := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+
+	t.Run("Plain signer", func(t *testing.T) {
+		sig, err := crypto.NewSignerContext(signer).SignContext(context.Background(), []byte("message"))
+		require.NoError(t, err)
+
+		valid, err := privateKey.PublicKey().Verify(sig, []byte("message"), crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := crypto.NewSignerContext(signer).SignContext(ctx, []byte("message"))
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("Context signer", func(t *testing.T) {
+		s := &contextSigner{Signer: signer}
+
+		assert.Equal(t, s, crypto.NewSignerContext(s))
+	})
+}
+
+func TestDeterministicSigner(t *testing.T) {
+	seed := []byte("elephant ears space cowboy octopus rodeo potato cannon pineapple")
+
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		t.Run(sigAlgo.String(), func(t *testing.T) {
+			signerA, err := crypto.NewDeterministicSigner(seed, sigAlgo, crypto.SHA3_256)
+			require.NoError(t, err)
+
+			signerB, err := crypto.NewDeterministicSigner(seed, sigAlgo, crypto.SHA3_256)
+			require.NoError(t, err)
+
+			message := []byte("hello world")
+
+			sigA, err := signerA.Sign(message)
+			require.NoError(t, err)
+
+			sigB, err := signerB.Sign(message)
+			require.NoError(t, err)
+
+			assert.Equal(t, sigA, sigB)
+
+			valid, err := signerA.PrivateKey.PublicKey().Verify(sigA, message, crypto.NewSHA3_256())
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			other, err := signerA.Sign([]byte("goodbye world"))
+			require.NoError(t, err)
+			assert.NotEqual(t, sigA, other)
+		})
+	}
+
+	t.Run("Transaction ID", func(t *testing.T) {
+		sign := func() flow.Identifier {
+			signer, err := crypto.NewDeterministicSigner(seed, crypto.ECDSA_P256, crypto.SHA3_256)
+			require.NoError(t, err)
+
+			tx := test.TransactionGenerator().New()
+			tx.EnvelopeSignatures = nil
+
+			err = tx.SignEnvelope(tx.Payer, 0, signer)
+			require.NoError(t, err)
+
+			return tx.ID()
+		}
+
+		assert.Equal(t, sign(), sign())
+	})
+
+	t.Run("Short seed", func(t *testing.T) {
+		_, err := crypto.NewDeterministicSigner([]byte("short"), crypto.ECDSA_P256, crypto.SHA3_256)
+		assert.Error(t, err)
+	})
+}
+
+func TestGeneratePrivateKeyIterated(t *testing.T) {
+	seed := []byte("elephant ears space cowboy octopus rodeo potato cannon pineapple")
+
+	t.Run("Single round", func(t *testing.T) {
+		expected, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+		require.NoError(t, err)
+
+		privateKey, err := crypto.GeneratePrivateKeyIterated(crypto.ECDSA_P256, seed, 1)
+		require.NoError(t, err)
+
+		assert.True(t, expected.Equal(privateKey))
+	})
+
+	t.Run("Multiple rounds", func(t *testing.T) {
+		derived := sha3.Sum384(seed)
+		derived = sha3.Sum384(derived[:])
+
+		expected, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, derived[:])
+		require.NoError(t, err)
+
+		privateKey, err := crypto.GeneratePrivateKeyIterated(crypto.ECDSA_P256, seed, 3)
+		require.NoError(t, err)
+
+		assert.True(t, expected.Equal(privateKey))
+		assert.Equal(t,
+			"5ef569df3a6258ab427ac3e2b40e794bfa5d255a5d16ce1d64db800a47f30248",
+			hex.EncodeToString(privateKey.Encode()),
+		)
+	})
+
+	t.Run("Zero rounds", func(t *testing.T) {
+		_, err := crypto.GeneratePrivateKeyIterated(crypto.ECDSA_P256, seed, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("Short seed", func(t *testing.T) {
+		_, err := crypto.GeneratePrivateKeyIterated(crypto.ECDSA_secp256k1, []byte("short"), 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestSignRecoverable(t *testing.T) {
+	// private key with the Ethereum address 0x970e8128ab834e8eac17ab8e3812f010678cf791
+	privateKey, err := crypto.DecodePrivateKeyHex(
+		crypto.ECDSA_secp256k1,
+		"289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032",
+	)
+	require.NoError(t, err)
+
+	signer := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+
+	message := []byte("hello world")
+	digest := crypto.NewSHA3_256().ComputeHash(message)
+
+	t.Run("Sign and recover", func(t *testing.T) {
+		sig, err := crypto.SignRecoverable(signer, message)
+		require.NoError(t, err)
+		require.Len(t, sig, crypto.RecoverableSignatureLength)
+
+		publicKey, err := crypto.RecoverPublicKey(sig, digest)
+		require.NoError(t, err)
+
+		assert.Equal(t, privateKey.PublicKey().Encode(), publicKey.Encode())
+
+		// r || s is a valid Flow signature
+		valid, err := privateKey.PublicKey().Verify(sig[:64], message, crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Known signature", func(t *testing.T) {
+		sig, err := hex.DecodeString(
+			"3ff29b6f154a7edec8b478ae4761df6cbb9c777ab6eb35fd983dacc8675a8704" +
+				"2ec1a0e316050f7e89d3b77c24600349d3645e8e8f431e1a10ab67f21ca019f4" +
+				"01",
+		)
+		require.NoError(t, err)
+
+		publicKey, err := crypto.RecoverPublicKey(sig, digest)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"7db227d7094ce215c3a0f57e1bcc732551fe351f94249471934567e0f5dc1bf7"+
+				"95962b8cccb87a2eb56b29fbe37d614e2f4c3c45b789ae4f1f51f4cb21972ffd",
+			hex.EncodeToString(publicKey.Encode()),
+		)
+	})
+
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		p256Key, err := crypto.GeneratePrivateKey(
+			crypto.ECDSA_P256,
+			[]byte("elephant ears space cowboy octopus rodeo potato cannon pineapple"),
+		)
+		require.NoError(t, err)
+
+		_, err = crypto.SignRecoverable(crypto.NewInMemorySigner(p256Key, crypto.SHA3_256), message)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsupported signer", func(t *testing.T) {
+		callbackSigner := crypto.CallbackSigner(signer.Sign)
+
+		_, err := crypto.SignRecoverable(callbackSigner, message)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid signature length", func(t *testing.T) {
+		_, err := crypto.RecoverPublicKey(make([]byte, 64), digest)
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyType_String(t *testing.T) {
+	tests := []struct {
+		keyType crypto.KeyType
+		s       string
+	}{
+		{crypto.ECDSA_P256_SHA2_256, "ECDSA_P256_SHA2_256"},
+		{crypto.ECDSA_P256_SHA3_256, "ECDSA_P256_SHA3_256"},
+		{crypto.ECDSA_secp256k1_SHA2_256, "ECDSA_secp256k1_SHA2_256"},
+		{crypto.ECDSA_secp256k1_SHA3_256, "ECDSA_secp256k1_SHA3_256"},
+		{crypto.UnknownKeyType, "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.s, tt.keyType.String())
+			assert.Equal(t, tt.keyType, crypto.StringToKeyType(tt.s))
+		})
+	}
+
+	t.Run("Unrecognized", func(t *testing.T) {
+		assert.Equal(t, crypto.UnknownKeyType, crypto.StringToKeyType("ECDSA_P256"))
+		assert.Equal(t, crypto.UnknownKeyType, crypto.StringToKeyType(""))
+	})
+}
+
+func TestKey_Equal(t *testing.T) {
+	seedA := []byte("elephant ears space cowboy octopus rodeo potato cannon pineapple")
+	seedB := []byte("pineapple cannon potato rodeo octopus cowboy space ears elephant")
+
+	p256KeyA, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seedA)
+	require.NoError(t, err)
+
+	p256KeyB, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seedB)
+	require.NoError(t, err)
+
+	secp256k1KeyA, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, seedA)
+	require.NoError(t, err)
+
+	decodedKeyA, err := crypto.DecodePrivateKey(crypto.ECDSA_P256, p256KeyA.Encode())
+	require.NoError(t, err)
+
+	t.Run("Private keys", func(t *testing.T) {
+		assert.True(t, p256KeyA.Equal(p256KeyA))
+		assert.True(t, p256KeyA.Equal(decodedKeyA))
+		assert.False(t, p256KeyA.Equal(p256KeyB))
+		assert.False(t, p256KeyA.Equal(secp256k1KeyA))
+		assert.False(t, p256KeyA.Equal(crypto.PrivateKey{}))
+		assert.True(t, crypto.PrivateKey{}.Equal(crypto.PrivateKey{}))
+	})
+
+	t.Run("Public keys", func(t *testing.T) {
+		assert.True(t, p256KeyA.PublicKey().Equal(p256KeyA.PublicKey()))
+		assert.True(t, p256KeyA.PublicKey().Equal(decodedKeyA.PublicKey()))
+		assert.False(t, p256KeyA.PublicKey().Equal(p256KeyB.PublicKey()))
+		assert.False(t, p256KeyA.PublicKey().Equal(secp256k1KeyA.PublicKey()))
+		assert.False(t, p256KeyA.PublicKey().Equal(crypto.PublicKey{}))
+		assert.True(t, crypto.PublicKey{}.Equal(crypto.PublicKey{}))
+	})
+}
+
+func TestPrivateKey_MatchesPublicKey(t *testing.T) {
+	seedA := []byte("elephant ears space cowboy octopus rodeo potato cannon pineapple")
+	seedB := []byte("pineapple cannon potato rodeo octopus cowboy space ears elephant")
+
+	p256KeyA, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seedA)
+	require.NoError(t, err)
+
+	p256KeyB, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seedB)
+	require.NoError(t, err)
+
+	secp256k1KeyA, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, seedA)
+	require.NoError(t, err)
+
+	decodedPublicKey, err := crypto.DecodePublicKey(crypto.ECDSA_P256, p256KeyA.PublicKey().Encode())
+	require.NoError(t, err)
+
+	assert.True(t, p256KeyA.MatchesPublicKey(p256KeyA.PublicKey()))
+	assert.True(t, p256KeyA.MatchesPublicKey(decodedPublicKey))
+	assert.False(t, p256KeyA.MatchesPublicKey(p256KeyB.PublicKey()))
+	assert.False(t, p256KeyA.MatchesPublicKey(secp256k1KeyA.PublicKey()))
+	assert.False(t, p256KeyA.MatchesPublicKey(crypto.PublicKey{}))
+	assert.False(t, crypto.PrivateKey{}.MatchesPublicKey(p256KeyA.PublicKey()))
+	assert.False(t, crypto.PrivateKey{}.MatchesPublicKey(crypto.PublicKey{}))
+}
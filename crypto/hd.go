@@ -0,0 +1,213 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// FlowCoinType is the BIP44 coin type registered for Flow.
+const FlowCoinType = 539
+
+// DefaultDerivationPath is the BIP44 derivation path of the first key of the first Flow account.
+const DefaultDerivationPath = "m/44'/539'/0'/0/0"
+
+// hardenedOffset is added to a child index to derive a hardened child key.
+const hardenedOffset uint32 = 1 << 31
+
+// DeriveKeyFromMnemonic derives a private key from a BIP39 mnemonic and an optional passphrase,
+// following the given BIP44 derivation path.
+//
+// The mnemonic must be an English BIP39 mnemonic with a valid checksum. The path has the form
+// "m/44'/539'/0'/0/0", where an apostrophe (or "h") marks a hardened index.
+//
+// Keys are derived as specified by SLIP-0010, which matches BIP32 for ECDSA_secp256k1 and
+// extends it to ECDSA_P256.
+func DeriveKeyFromMnemonic(mnemonic, passphrase, path string, sigAlgo SignatureAlgorithm) (PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return PrivateKey{}, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	return DeriveKeyFromSeed(seed, path, sigAlgo)
+}
+
+// DeriveKeyFromSeed derives a private key from a BIP32 seed, following the given derivation path.
+//
+// See DeriveKeyFromMnemonic for the supported path format and derivation scheme.
+func DeriveKeyFromSeed(seed []byte, path string, sigAlgo SignatureAlgorithm) (PrivateKey, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	var (
+		curveSeed string
+		n         *big.Int
+	)
+
+	switch sigAlgo {
+	case ECDSA_P256:
+		curveSeed = "Nist256p1 seed"
+		n = elliptic.P256().Params().N
+	case ECDSA_secp256k1:
+		curveSeed = "Bitcoin seed"
+		n = ethcrypto.S256().Params().N
+	default:
+		return PrivateKey{}, fmt.Errorf("key derivation is not supported for %s", sigAlgo)
+	}
+
+	key, chainCode := masterKey([]byte(curveSeed), seed, n)
+
+	for _, index := range indexes {
+		key, chainCode, err = childKey(key, chainCode, index, sigAlgo, n)
+		if err != nil {
+			return PrivateKey{}, err
+		}
+	}
+
+	return DecodePrivateKey(sigAlgo, key)
+}
+
+// masterKey returns the master private key and chain code for a seed.
+func masterKey(curveSeed, seed []byte, n *big.Int) ([]byte, []byte) {
+	data := seed
+
+	for {
+		I := hmacSHA512(curveSeed, data)
+		IL, IR := I[:32], I[32:]
+
+		k := new(big.Int).SetBytes(IL)
+		if k.Sign() != 0 && k.Cmp(n) < 0 {
+			return IL, IR
+		}
+
+		data = I
+	}
+}
+
+// childKey returns the private key and chain code of the child at the given index.
+func childKey(key, chainCode []byte, index uint32, sigAlgo SignatureAlgorithm, n *big.Int) ([]byte, []byte, error) {
+	var data []byte
+
+	if index >= hardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		parentKey, err := DecodePrivateKey(sigAlgo, key)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data = compressPublicKey(parentKey.PublicKey().Encode())
+	}
+
+	data = appendUint32(data, index)
+
+	parent := new(big.Int).SetBytes(key)
+
+	for {
+		I := hmacSHA512(chainCode, data)
+		IL, IR := I[:32], I[32:]
+
+		k := new(big.Int).SetBytes(IL)
+		if k.Cmp(n) < 0 {
+			k.Add(k, parent)
+			k.Mod(k, n)
+
+			if k.Sign() != 0 {
+				return leftPad(k.Bytes(), 32), IR, nil
+			}
+		}
+
+		data = appendUint32(append([]byte{1}, IR...), index)
+	}
+}
+
+// parseDerivationPath parses a derivation path of the form "m/44'/539'/0'/0/0".
+func parseDerivationPath(path string) ([]uint32, error) {
+	components := strings.Split(path, "/")
+	if components[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with \"m\"", path)
+	}
+
+	indexes := make([]uint32, 0, len(components)-1)
+
+	for _, component := range components[1:] {
+		hardened := strings.HasSuffix(component, "'") || strings.HasSuffix(component, "h")
+		if hardened {
+			component = component[:len(component)-1]
+		}
+
+		index, err := strconv.ParseUint(component, 10, 32)
+		if err != nil || index >= uint64(hardenedOffset) {
+			return nil, fmt.Errorf("invalid derivation path %q: invalid index %q", path, component)
+		}
+
+		if hardened {
+			index += uint64(hardenedOffset)
+		}
+
+		indexes = append(indexes, uint32(index))
+	}
+
+	return indexes, nil
+}
+
+// compressPublicKey converts a raw x || y public key to its compressed form.
+func compressPublicKey(raw []byte) []byte {
+	half := len(raw) / 2
+	x, y := raw[:half], raw[half:]
+
+	prefix := byte(0x02) | (y[len(y)-1] & 1)
+
+	return append([]byte{prefix}, x...)
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+
+	return padded
+}
@@ -0,0 +1,111 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func TestDeriveKeyFromSeed(t *testing.T) {
+	// SLIP-0010 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	vectors := []struct {
+		sigAlgo crypto.SignatureAlgorithm
+		path    string
+		key     string
+	}{
+		{crypto.ECDSA_secp256k1, "m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{crypto.ECDSA_secp256k1, "m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{crypto.ECDSA_secp256k1, "m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{crypto.ECDSA_secp256k1, "m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{crypto.ECDSA_P256, "m", "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2"},
+		{crypto.ECDSA_P256, "m/0'", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+		{crypto.ECDSA_P256, "m/0'/1", "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129"},
+		{crypto.ECDSA_P256, "m/0h/1/2h", "694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7"},
+		// SLIP-0010 derivation retry vectors
+		{crypto.ECDSA_P256, "m/28578'", "06f0db126f023755d0b8d86d4591718a5210dd8d024e3e14b6159d63f53aa669"},
+		{crypto.ECDSA_P256, "m/28578'/33941", "092154eed4af83e078ff9b84322015aefe5769e31270f62c3f66c33888335f3a"},
+	}
+
+	for _, v := range vectors {
+		privateKey, err := crypto.DeriveKeyFromSeed(seed, v.path, v.sigAlgo)
+		require.NoError(t, err)
+
+		assert.Equal(t, v.key, hex.EncodeToString(privateKey.Encode()), "%s %s", v.sigAlgo, v.path)
+	}
+}
+
+func TestDeriveKeyFromMnemonic(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	t.Run("Matches seed derivation", func(t *testing.T) {
+		// BIP39 test vector for the mnemonic above with passphrase "TREZOR"
+		seed, _ := hex.DecodeString(
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531" +
+				"f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		)
+
+		for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+			fromMnemonic, err := crypto.DeriveKeyFromMnemonic(mnemonic, "TREZOR", crypto.DefaultDerivationPath, sigAlgo)
+			require.NoError(t, err)
+
+			fromSeed, err := crypto.DeriveKeyFromSeed(seed, crypto.DefaultDerivationPath, sigAlgo)
+			require.NoError(t, err)
+
+			assert.True(t, fromMnemonic.Equal(fromSeed))
+			assert.Equal(t, sigAlgo, fromMnemonic.Algorithm())
+		}
+	})
+
+	t.Run("Passphrase changes key", func(t *testing.T) {
+		a, err := crypto.DeriveKeyFromMnemonic(mnemonic, "", crypto.DefaultDerivationPath, crypto.ECDSA_P256)
+		require.NoError(t, err)
+
+		b, err := crypto.DeriveKeyFromMnemonic(mnemonic, "TREZOR", crypto.DefaultDerivationPath, crypto.ECDSA_P256)
+		require.NoError(t, err)
+
+		assert.False(t, a.Equal(b))
+	})
+
+	t.Run("Invalid checksum", func(t *testing.T) {
+		invalid := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"
+
+		_, err := crypto.DeriveKeyFromMnemonic(invalid, "", crypto.DefaultDerivationPath, crypto.ECDSA_P256)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid path", func(t *testing.T) {
+		for _, path := range []string{"", "44'/539'", "m/44'/x", "m//0", "m/2147483648", "m/0''"} {
+			_, err := crypto.DeriveKeyFromMnemonic(mnemonic, "", path, crypto.ECDSA_P256)
+			assert.Error(t, err, path)
+		}
+	})
+
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		_, err := crypto.DeriveKeyFromMnemonic(mnemonic, "", crypto.DefaultDerivationPath, crypto.BLS_BLS12381)
+		assert.Error(t, err)
+	})
+}
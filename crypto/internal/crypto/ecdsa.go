@@ -179,23 +179,82 @@ func (a *ecdsaAlgo) decodePrivateKey(der []byte) (PrivateKey, error) {
 	return a.rawDecodePrivateKey(der)
 }
 
+// rawDecodePublicKey decodes a public key from either the raw uncompressed
+// encoding bytes(x)||bytes(y), or the compressed encoding prefix||bytes(x)
+// where the prefix is 0x02 if y is even and 0x03 if y is odd (SEC 1 section 2.3.3).
+// The decoded point must be on the curve.
 func (a *ecdsaAlgo) rawDecodePublicKey(der []byte) (PublicKey, error) {
 	Plen := bitsToBytes((a.curve.Params().P).BitLen())
-	if len(der) != 2*Plen {
+
+	var x, y *big.Int
+
+	switch len(der) {
+	case 2 * Plen:
+		x = new(big.Int).SetBytes(der[:Plen])
+		y = new(big.Int).SetBytes(der[Plen:])
+	case Plen + 1:
+		x = new(big.Int).SetBytes(der[1:])
+
+		var err error
+		y, err = a.decompressY(der[0], x)
+		if err != nil {
+			return nil, err
+		}
+	default:
 		return nil, errors.New("raw public key is not valid")
 	}
-	var x, y big.Int
-	x.SetBytes(der[:Plen])
-	y.SetBytes(der[Plen:])
+
+	if !a.curve.IsOnCurve(x, y) {
+		return nil, errors.New("raw public key is not on the curve")
+	}
 
 	pk := goecdsa.PublicKey{
 		Curve: a.curve,
-		X:     &x,
-		Y:     &y,
+		X:     x,
+		Y:     y,
 	}
 	return &PubKeyECDSA{a, &pk}, nil
 }
 
+// decompressY returns the y coordinate of the curve point with the given x coordinate,
+// choosing the root of y² = x³ + ax + b with the parity given by the compressed
+// encoding prefix.
+func (a *ecdsaAlgo) decompressY(prefix byte, x *big.Int) (*big.Int, error) {
+	if prefix != 0x02 && prefix != 0x03 {
+		return nil, errors.New("compressed public key prefix is not valid")
+	}
+
+	params := a.curve.Params()
+	if x.Cmp(params.P) >= 0 {
+		return nil, errors.New("raw public key is not on the curve")
+	}
+
+	// x³ + b
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x)
+	y2.Add(y2, params.B)
+
+	// SECG curves have a=0, NIST curves have a=-3
+	if _, isSEC := a.curve.(*SECCurve); !isSEC {
+		threeX := new(big.Int).Lsh(x, 1)
+		threeX.Add(threeX, x)
+		y2.Sub(y2, threeX)
+	}
+
+	y2.Mod(y2, params.P)
+
+	y := new(big.Int).ModSqrt(y2, params.P)
+	if y == nil {
+		return nil, errors.New("raw public key is not on the curve")
+	}
+
+	if y.Bit(0) != uint(prefix&1) {
+		y.Sub(params.P, y)
+	}
+
+	return y, nil
+}
+
 func (a *ecdsaAlgo) decodePublicKey(der []byte) (PublicKey, error) {
 	return a.rawDecodePublicKey(der)
 }
@@ -120,3 +120,91 @@ func TestECDSAUtils(t *testing.T) {
 		testKeySize(t, sk, ecdsaPrKeyLen[i], ecdsaPubKeyLen[i])
 	}
 }
+
+// TestECDSACompressedPublicKey tests that a public key decoded from its compressed
+// encoding verifies signatures identically to the same key decoded from its raw encoding
+func TestECDSACompressedPublicKey(t *testing.T) {
+	ecdsaCurves := []SigningAlgorithm{
+		ECDSAP256,
+		ECDSASecp256k1,
+	}
+
+	for _, curve := range ecdsaCurves {
+		t.Run(curve.String(), func(t *testing.T) {
+			halg := hash.NewSHA3_256()
+
+			for i := 0; i < 20; i++ {
+				seed := make([]byte, KeyGenSeedMinLenECDSASecp256k1)
+				_, err := rand.Read(seed)
+				require.NoError(t, err)
+
+				sk, err := GeneratePrivateKey(curve, seed)
+				require.NoError(t, err)
+
+				message := []byte("compressed public key")
+				sig, err := sk.Sign(message, halg)
+				require.NoError(t, err)
+
+				encoded := sk.PublicKey().Encode()
+				xLen := len(encoded) / 2
+
+				// the prefix is 0x02 for an even y and 0x03 for an odd y
+				compressed := append([]byte{0x02 | encoded[len(encoded)-1]&1}, encoded[:xLen]...)
+
+				uncompressedPk, err := DecodePublicKey(curve, encoded)
+				require.NoError(t, err)
+
+				compressedPk, err := DecodePublicKey(curve, compressed)
+				require.NoError(t, err)
+
+				require.True(t, compressedPk.Equals(uncompressedPk))
+				require.Equal(t, encoded, compressedPk.Encode())
+
+				valid, err := uncompressedPk.Verify(sig, message, halg)
+				require.NoError(t, err)
+				require.True(t, valid)
+
+				valid, err = compressedPk.Verify(sig, message, halg)
+				require.NoError(t, err)
+				require.True(t, valid)
+
+				// the opposite parity decodes to the negated point, which does not verify
+				compressed[0] ^= 1
+				negatedPk, err := DecodePublicKey(curve, compressed)
+				require.NoError(t, err)
+
+				valid, err = negatedPk.Verify(sig, message, halg)
+				require.NoError(t, err)
+				require.False(t, valid)
+			}
+		})
+	}
+}
+
+// TestECDSAPublicKeyNotOnCurve tests that points off the curve are rejected
+func TestECDSAPublicKeyNotOnCurve(t *testing.T) {
+	ecdsaCurves := []SigningAlgorithm{
+		ECDSAP256,
+		ECDSASecp256k1,
+	}
+
+	for _, curve := range ecdsaCurves {
+		t.Run(curve.String(), func(t *testing.T) {
+			seed := make([]byte, KeyGenSeedMinLenECDSASecp256k1)
+			_, err := rand.Read(seed)
+			require.NoError(t, err)
+
+			sk, err := GeneratePrivateKey(curve, seed)
+			require.NoError(t, err)
+
+			encoded := sk.PublicKey().Encode()
+			encoded[len(encoded)-1] ^= 1
+
+			_, err = DecodePublicKey(curve, encoded)
+			require.Error(t, err)
+
+			_, err = DecodePublicKey(curve, append([]byte{0x04}, encoded[:len(encoded)/2]...))
+			require.Error(t, err)
+		})
+	}
+}
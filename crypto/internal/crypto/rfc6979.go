@@ -0,0 +1,170 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	stdhash "hash"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/flow-go-sdk/crypto/internal/crypto/hash"
+)
+
+// SignDeterministic signs an array of bytes with an ECDSA private key, using a nonce
+// derived from the private key and the message hash as defined in RFC 6979.
+//
+// Nonces are generated with HMAC using the hash algorithm of the message hasher, so the same
+// key, message and hasher always produce the same signature.
+// The resulting signature is the concatenation bytes(r)||bytes(s)
+// where r and s are padded to the curve order size.
+func SignDeterministic(sk PrivateKey, data []byte, alg hash.Hasher) (Signature, error) {
+	if alg == nil {
+		return nil, errors.New("Sign requires a Hasher")
+	}
+
+	ecdsaKey, ok := sk.(*PrKeyECDSA)
+	if !ok {
+		return nil, fmt.Errorf("deterministic signing is not supported for %s", sk.Algorithm())
+	}
+
+	newHMACHash, err := hmacHashFunc(alg.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	h := alg.ComputeHash(data)
+	return ecdsaKey.signHashDeterministic(h, newHMACHash)
+}
+
+// hmacHashFunc returns the hash function used to generate nonces for the given hash algorithm.
+func hmacHashFunc(algo hash.HashingAlgorithm) (func() stdhash.Hash, error) {
+	switch algo {
+	case hash.SHA2_256:
+		return sha256.New, nil
+	case hash.SHA2_384:
+		return sha512.New384, nil
+	case hash.SHA3_256:
+		return sha3.New256, nil
+	case hash.SHA3_384:
+		return sha3.New384, nil
+	default:
+		return nil, fmt.Errorf("deterministic signing is not supported for hash algorithm %s", algo)
+	}
+}
+
+// signHashDeterministic returns the signature of the hash using an RFC 6979 nonce,
+// generated with HMAC over the given hash function.
+func (sk *PrKeyECDSA) signHashDeterministic(h hash.Hash, newHMACHash func() stdhash.Hash) (Signature, error) {
+	curve := sk.alg.curve
+	n := curve.Params().N
+	d := sk.goPrKey.D
+
+	Nlen := bitsToBytes(n.BitLen())
+
+	e := bits2int(h, n)
+
+	x := int2octets(d, Nlen)
+	h1 := int2octets(new(big.Int).Mod(e, n), Nlen)
+
+	hmacSize := newHMACHash().Size()
+
+	V := make([]byte, hmacSize)
+	K := make([]byte, hmacSize)
+	for i := range V {
+		V[i] = 0x01
+	}
+
+	K = hmacHash(newHMACHash, K, V, []byte{0x00}, x, h1)
+	V = hmacHash(newHMACHash, K, V)
+	K = hmacHash(newHMACHash, K, V, []byte{0x01}, x, h1)
+	V = hmacHash(newHMACHash, K, V)
+
+	for {
+		var T []byte
+		for len(T) < Nlen {
+			V = hmacHash(newHMACHash, K, V)
+			T = append(T, V...)
+		}
+
+		k := bits2int(T[:Nlen], n)
+
+		if k.Sign() > 0 && k.Cmp(n) < 0 {
+			rx, _ := curve.ScalarBaseMult(int2octets(k, Nlen))
+			r := new(big.Int).Mod(rx, n)
+
+			if r.Sign() != 0 {
+				s := new(big.Int).Mul(r, d)
+				s.Add(s, e)
+				s.Mul(s, new(big.Int).ModInverse(k, n))
+				s.Mod(s, n)
+
+				if s.Sign() != 0 {
+					signature := make([]byte, 2*Nlen)
+					rBytes := r.Bytes()
+					sBytes := s.Bytes()
+					copy(signature[Nlen-len(rBytes):], rBytes)
+					copy(signature[2*Nlen-len(sBytes):], sBytes)
+					return signature, nil
+				}
+			}
+		}
+
+		K = hmacHash(newHMACHash, K, V, []byte{0x00})
+		V = hmacHash(newHMACHash, K, V)
+	}
+}
+
+// bits2int converts a byte string to an integer, keeping only the leftmost
+// bits up to the bit length of the curve order.
+func bits2int(b []byte, n *big.Int) *big.Int {
+	i := new(big.Int).SetBytes(b)
+
+	excess := len(b)*8 - n.BitLen()
+	if excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+
+	return i
+}
+
+// int2octets converts an integer to a big-endian byte string of the given length.
+func int2octets(i *big.Int, size int) []byte {
+	b := i.Bytes()
+	if len(b) >= size {
+		return b
+	}
+
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
+
+func hmacHash(newHash func() stdhash.Hash, key []byte, data ...[]byte) []byte {
+	mac := hmac.New(newHash, key)
+	for _, d := range data {
+		_, _ = mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,112 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto/internal/crypto/hash"
+)
+
+// TestSignDeterministic tests deterministic signatures against the RFC 6979 test vectors
+func TestSignDeterministic(t *testing.T) {
+	// RFC 6979, A.2.5: ECDSA, 256 Bits (Prime Field), SHA-256
+	prKeyBytes, _ := hex.DecodeString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+
+	sk, err := DecodePrivateKey(ECDSAP256, prKeyBytes)
+	require.NoError(t, err)
+
+	vectors := []struct {
+		message   string
+		signature string
+	}{
+		{
+			"sample",
+			"efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716" +
+				"f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+		},
+		{
+			"test",
+			"f1abb023518351cd71d881567b1ea663ed3efcf6c5132b354f28d3b0b7d38367" +
+				"019f4113742a2b14bd25926b49c649155f267e60d3814b4c0cc84250e46f0083",
+		},
+	}
+
+	for _, v := range vectors {
+		sig, err := SignDeterministic(sk, []byte(v.message), hash.NewSHA2_256())
+		require.NoError(t, err)
+
+		assert.Equal(t, v.signature, hex.EncodeToString(sig), v.message)
+
+		valid, err := sk.PublicKey().Verify(sig, []byte(v.message), hash.NewSHA2_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	t.Run("SHA-384", func(t *testing.T) {
+		// RFC 6979, A.2.5: ECDSA, 256 Bits (Prime Field), SHA-384
+		vectors := []struct {
+			message   string
+			signature string
+		}{
+			{
+				"sample",
+				"0eafea039b20e9b42309fb1d89e213057cbf973dc0cfc8f129edddc800ef7719" +
+					"4861f0491e6998b9455193e34e7b0d284ddd7149a74b95b9261f13abde940954",
+			},
+			{
+				"test",
+				"83910e8b48bb0c74244ebdf7f07a1c5413d61472bd941ef3920e623fbccebeb6" +
+					"8ddbec54cf8cd5874883841d712142a56a8d0f218f5003cb0296b6b509619f2c",
+			},
+		}
+
+		for _, v := range vectors {
+			sig, err := SignDeterministic(sk, []byte(v.message), hash.NewSHA2_384())
+			require.NoError(t, err)
+
+			assert.Equal(t, v.signature, hex.EncodeToString(sig), v.message)
+		}
+	})
+
+	t.Run("secp256k1", func(t *testing.T) {
+		seed := make([]byte, KeyGenSeedMinLenECDSASecp256k1)
+
+		sk, err := GeneratePrivateKey(ECDSASecp256k1, seed)
+		require.NoError(t, err)
+
+		message := []byte("message")
+
+		sigA, err := SignDeterministic(sk, message, hash.NewSHA3_256())
+		require.NoError(t, err)
+
+		sigB, err := SignDeterministic(sk, message, hash.NewSHA3_256())
+		require.NoError(t, err)
+
+		assert.Equal(t, sigA, sigB)
+
+		valid, err := sk.PublicKey().Verify(sigA, message, hash.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
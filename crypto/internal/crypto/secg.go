@@ -127,6 +127,27 @@ func (curve *SECCurve) addJacobian(x1, y1, z1, x2, y2, z2 *big.Int) (*big.Int, *
 	return x3, y3, z3
 }
 
+// IsOnCurve reports whether the given (x,y) lies on the curve y² = x³ + b.
+// (rewritten for a=0, the CurveParams implementation assumes a=-3)
+func (curve *SECCurve) IsOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(curve.P) >= 0 ||
+		y.Sign() < 0 || y.Cmp(curve.P) >= 0 {
+		return false
+	}
+
+	// y²
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, curve.P)
+
+	// x³ + b
+	x3 := new(big.Int).Mul(x, x)
+	x3.Mul(x3, x)
+	x3.Add(x3, curve.B)
+	x3.Mod(x3, curve.P)
+
+	return x3.Cmp(y2) == 0
+}
+
 // Double returns 2*(x,y)
 // (taken from Go/crypto/elliptic)
 func (curve *SECCurve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
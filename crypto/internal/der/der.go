@@ -0,0 +1,95 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package der decodes the DER-encoded ECDSA signatures and public keys returned
+// by key management services into the raw formats used by Flow.
+package der
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ParseSignature converts an ASN.1 DER-encoded ECDSA signature into the raw r || s form,
+// with each value left-padded to the given size in bytes.
+func ParseSignature(der []byte, size int) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DER signature: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("invalid DER signature: trailing data")
+	}
+
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, errors.New("invalid DER signature: values must be positive")
+	}
+
+	rBytes := sig.R.Bytes()
+	sBytes := sig.S.Bytes()
+
+	if len(rBytes) > size || len(sBytes) > size {
+		return nil, fmt.Errorf("invalid DER signature: values exceed %d bytes", size)
+	}
+
+	raw := make([]byte, 2*size)
+	copy(raw[size-len(rBytes):size], rBytes)
+	copy(raw[2*size-len(sBytes):], sBytes)
+
+	return raw, nil
+}
+
+// ParsePublicKey returns the raw x || y coordinates of the ECDSA public key in an
+// ASN.1 DER-encoded SubjectPublicKeyInfo structure.
+//
+// The key must be an uncompressed point. The curve is not checked, so that keys on
+// curves unknown to the standard library, such as secp256k1, can be parsed.
+func ParsePublicKey(der []byte) ([]byte, error) {
+	var info struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.RawValue `asn1:"optional"`
+		}
+		PublicKey asn1.BitString
+	}
+
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DER public key: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("invalid DER public key: trailing data")
+	}
+
+	point := info.PublicKey.RightAlign()
+
+	// uncompressed points are prefixed with 0x04
+	if len(point) == 0 || point[0] != 0x04 || len(point)%2 != 1 {
+		return nil, errors.New("invalid DER public key: not an uncompressed point")
+	}
+
+	return point[1:], nil
+}
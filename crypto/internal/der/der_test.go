@@ -0,0 +1,77 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package der_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto/internal/der"
+)
+
+func TestParseSignature(t *testing.T) {
+	t.Run("Padded values", func(t *testing.T) {
+		encoded, err := asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(1), big.NewInt(0x0203)})
+		require.NoError(t, err)
+
+		sig, err := der.ParseSignature(encoded, 4)
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte{0, 0, 0, 1, 0, 0, 2, 3}, sig)
+	})
+
+	t.Run("Values too large", func(t *testing.T) {
+		encoded, err := asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(0x010203), big.NewInt(1)})
+		require.NoError(t, err)
+
+		_, err = der.ParseSignature(encoded, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := der.ParseSignature([]byte{0x30, 0x01}, 32)
+		assert.Error(t, err)
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encoded, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	raw, err := der.ParsePublicKey(encoded)
+	require.NoError(t, err)
+
+	require.Len(t, raw, 64)
+	assert.Equal(t, key.X, new(big.Int).SetBytes(raw[:32]))
+	assert.Equal(t, key.Y, new(big.Int).SetBytes(raw[32:]))
+
+	_, err = der.ParsePublicKey(encoded[:len(encoded)-1])
+	assert.Error(t, err)
+}
@@ -0,0 +1,322 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// PEM block types of the standard key formats written and read by the PEM functions.
+const (
+	pemECPrivateKey = "EC PRIVATE KEY" // SEC 1
+	pemPrivateKey   = "PRIVATE KEY"    // PKCS #8
+	pemPublicKey    = "PUBLIC KEY"     // SubjectPublicKeyInfo
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	oidNamedCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// ecPrivateKey is the SEC 1 ECPrivateKey structure.
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8PrivateKey is the PKCS #8 PrivateKeyInfo structure.
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// subjectPublicKeyInfo is the X.509 SubjectPublicKeyInfo structure.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// EncodePrivateKeyPEM encodes a private key as an "EC PRIVATE KEY" PEM block.
+//
+// The block contains the SEC 1 ECPrivateKey structure, with the named curve of the key
+// and its public key, as written by OpenSSL.
+func EncodePrivateKeyPEM(pk PrivateKey) ([]byte, error) {
+	if pk.privateKey == nil {
+		return nil, errors.New("private key is empty")
+	}
+
+	curve, err := namedCurveOID(pk.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    pk.Encode(),
+		NamedCurveOID: curve,
+		PublicKey:     uncompressedPoint(pk.PublicKey()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemECPrivateKey, Bytes: der}), nil
+}
+
+// EncodePublicKeyPEM encodes a public key as a "PUBLIC KEY" PEM block.
+//
+// The block contains the X.509 SubjectPublicKeyInfo structure, with the named curve of the
+// key and the key as an uncompressed point.
+func EncodePublicKeyPEM(pk PublicKey) ([]byte, error) {
+	if pk.publicKey == nil {
+		return nil, errors.New("public key is empty")
+	}
+
+	algorithm, err := ecdsaAlgorithmIdentifier(pk.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithm,
+		PublicKey: uncompressedPoint(pk),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM decodes a private key from a SEC 1 "EC PRIVATE KEY" or a
+// PKCS #8 "PRIVATE KEY" PEM block.
+//
+// The key must be on the P-256 or secp256k1 curve.
+func DecodePrivateKeyPEM(b []byte) (PrivateKey, error) {
+	block, err := decodePEM(b)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	switch block.Type {
+	case pemECPrivateKey:
+		return parseECPrivateKey(block.Bytes, nil)
+	case pemPrivateKey:
+		var info pkcs8PrivateKey
+		err := unmarshalDER(block.Bytes, &info)
+		if err != nil {
+			return PrivateKey{}, fmt.Errorf("invalid PKCS #8 private key: %w", err)
+		}
+
+		curve, err := ecdsaCurveOID(info.Algorithm)
+		if err != nil {
+			return PrivateKey{}, err
+		}
+
+		return parseECPrivateKey(info.PrivateKey, curve)
+	default:
+		return PrivateKey{}, fmt.Errorf(
+			"unexpected PEM block type %q, expected %q or %q",
+			block.Type,
+			pemECPrivateKey,
+			pemPrivateKey,
+		)
+	}
+}
+
+// DecodePublicKeyPEM decodes a public key from a "PUBLIC KEY" PEM block containing an
+// X.509 SubjectPublicKeyInfo structure.
+//
+// The key must be an uncompressed point on the P-256 or secp256k1 curve.
+func DecodePublicKeyPEM(b []byte) (PublicKey, error) {
+	block, err := decodePEM(b)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	if block.Type != pemPublicKey {
+		return PublicKey{}, fmt.Errorf("unexpected PEM block type %q, expected %q", block.Type, pemPublicKey)
+	}
+
+	var info subjectPublicKeyInfo
+	err = unmarshalDER(block.Bytes, &info)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	curve, err := ecdsaCurveOID(info.Algorithm)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	sigAlgo, err := curveSignatureAlgorithm(curve)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	return decodeUncompressedPoint(sigAlgo, info.PublicKey)
+}
+
+// parseECPrivateKey parses a SEC 1 ECPrivateKey structure. The curve is taken from the
+// structure if it is not given.
+func parseECPrivateKey(der []byte, curve asn1.ObjectIdentifier) (PrivateKey, error) {
+	var key ecPrivateKey
+	err := unmarshalDER(der, &key)
+	if err != nil {
+		return PrivateKey{}, fmt.Errorf("invalid EC private key: %w", err)
+	}
+
+	if key.Version != 1 {
+		return PrivateKey{}, fmt.Errorf("unsupported EC private key version %d", key.Version)
+	}
+
+	if len(key.NamedCurveOID) > 0 {
+		if curve != nil && !curve.Equal(key.NamedCurveOID) {
+			return PrivateKey{}, errors.New("mismatched curves in private key")
+		}
+
+		curve = key.NamedCurveOID
+	}
+
+	if curve == nil {
+		return PrivateKey{}, errors.New("private key does not name its curve")
+	}
+
+	sigAlgo, err := curveSignatureAlgorithm(curve)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	privateKey, err := DecodePrivateKey(sigAlgo, key.PrivateKey)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	if key.PublicKey.BitLength > 0 {
+		publicKey, err := decodeUncompressedPoint(sigAlgo, key.PublicKey)
+		if err != nil {
+			return PrivateKey{}, err
+		}
+
+		if !privateKey.PublicKey().Equal(publicKey) {
+			return PrivateKey{}, errors.New("public key does not match private key")
+		}
+	}
+
+	return privateKey, nil
+}
+
+func decodePEM(b []byte) (*pem.Block, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	return block, nil
+}
+
+func unmarshalDER(der []byte, v interface{}) error {
+	rest, err := asn1.Unmarshal(der, v)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) != 0 {
+		return errors.New("trailing data")
+	}
+
+	return nil
+}
+
+func namedCurveOID(sigAlgo SignatureAlgorithm) (asn1.ObjectIdentifier, error) {
+	switch sigAlgo {
+	case ECDSA_P256:
+		return oidNamedCurveP256, nil
+	case ECDSA_secp256k1:
+		return oidNamedCurveSecp256k1, nil
+	default:
+		return nil, fmt.Errorf("signature algorithm %s is not supported in PEM", sigAlgo)
+	}
+}
+
+func curveSignatureAlgorithm(curve asn1.ObjectIdentifier) (SignatureAlgorithm, error) {
+	switch {
+	case curve.Equal(oidNamedCurveP256):
+		return ECDSA_P256, nil
+	case curve.Equal(oidNamedCurveSecp256k1):
+		return ECDSA_secp256k1, nil
+	default:
+		return UnknownSignatureAlgorithm, fmt.Errorf("unsupported curve %s", curve)
+	}
+}
+
+func ecdsaAlgorithmIdentifier(sigAlgo SignatureAlgorithm) (pkix.AlgorithmIdentifier, error) {
+	curve, err := namedCurveOID(sigAlgo)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+
+	parameters, err := asn1.Marshal(curve)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidPublicKeyECDSA,
+		Parameters: asn1.RawValue{FullBytes: parameters},
+	}, nil
+}
+
+// ecdsaCurveOID returns the named curve of an ECDSA algorithm identifier.
+func ecdsaCurveOID(algorithm pkix.AlgorithmIdentifier) (asn1.ObjectIdentifier, error) {
+	if !algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("unsupported key algorithm %s", algorithm.Algorithm)
+	}
+
+	var curve asn1.ObjectIdentifier
+	err := unmarshalDER(algorithm.Parameters.FullBytes, &curve)
+	if err != nil {
+		return nil, fmt.Errorf("invalid curve parameters: %w", err)
+	}
+
+	return curve, nil
+}
+
+// uncompressedPoint returns the SEC 1 uncompressed encoding 0x04 || x || y of a public key.
+func uncompressedPoint(pk PublicKey) asn1.BitString {
+	point := append([]byte{4}, pk.Encode()...)
+	return asn1.BitString{Bytes: point, BitLength: 8 * len(point)}
+}
+
+func decodeUncompressedPoint(sigAlgo SignatureAlgorithm, point asn1.BitString) (PublicKey, error) {
+	if point.BitLength%8 != 0 || len(point.Bytes) == 0 || point.Bytes[0] != 4 {
+		return PublicKey{}, errors.New("public key is not an uncompressed point")
+	}
+
+	return DecodePublicKey(sigAlgo, point.Bytes[1:])
+}
@@ -0,0 +1,100 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// RecoverableSignatureLength is the length of a recoverable secp256k1 signature.
+const RecoverableSignatureLength = 65
+
+// SignRecoverable signs the given message and returns a 65-byte recoverable
+// signature in the form r || s || v, where v is the recovery ID (0 or 1).
+//
+// The signer must be an in-memory signer with an ECDSA_secp256k1 private key
+// and a hasher that produces 32-byte digests. The message is hashed with the hasher
+// of the signer before signing, and the same digest must be passed to RecoverPublicKey.
+//
+// The returned signature is in the canonical form used by Ethereum, with s in the lower
+// half of the curve order. Its first 64 bytes are a valid Flow signature for the message.
+func SignRecoverable(signer Signer, message []byte) ([]byte, error) {
+	var inMemorySigner InMemorySigner
+
+	switch s := signer.(type) {
+	case InMemorySigner:
+		inMemorySigner = s
+	case *InMemorySigner:
+		inMemorySigner = *s
+	default:
+		return nil, fmt.Errorf("recoverable signatures require an in-memory signer, got %T", signer)
+	}
+
+	if inMemorySigner.PrivateKey.privateKey == nil {
+		return nil, errors.New("signer has no private key")
+	}
+
+	if inMemorySigner.PrivateKey.Algorithm() != ECDSA_secp256k1 {
+		return nil, fmt.Errorf(
+			"recoverable signatures are only supported for %s, got %s",
+			ECDSA_secp256k1,
+			inMemorySigner.PrivateKey.Algorithm(),
+		)
+	}
+
+	if inMemorySigner.Hasher == nil {
+		return nil, errors.New("signer has no hasher")
+	}
+
+	digest := inMemorySigner.Hasher.ComputeHash(message)
+
+	privateKey, err := ethcrypto.ToECDSA(inMemorySigner.PrivateKey.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	return ethcrypto.Sign(digest, privateKey)
+}
+
+// RecoverPublicKey recovers the ECDSA_secp256k1 public key that produced a recoverable
+// signature for the given 32-byte message digest.
+//
+// The signature must be in the r || s || v form returned by SignRecoverable.
+func RecoverPublicKey(sig, messageHash []byte) (PublicKey, error) {
+	if len(sig) != RecoverableSignatureLength {
+		return PublicKey{}, fmt.Errorf(
+			"recoverable signature must be %d bytes, got %d",
+			RecoverableSignatureLength,
+			len(sig),
+		)
+	}
+
+	publicKey, err := ethcrypto.SigToPub(messageHash, sig)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	// strip the 0x04 prefix of the uncompressed point encoding
+	encoded := ethcrypto.FromECDSAPub(publicKey)[1:]
+
+	return DecodePublicKey(ECDSA_secp256k1, encoded)
+}
@@ -19,7 +19,9 @@
 package flow
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 )
@@ -50,7 +52,7 @@ func (e Event) String() string {
 
 // ID returns the canonical SHA3-256 hash of this event.
 func (e Event) ID() string {
-	return DefaultHasher.ComputeHash(e.Encode()).Hex()
+	return canonicalHash(e.Encode()).Hex()
 }
 
 // Encode returns the canonical RLP byte representation of this event.
@@ -65,6 +67,26 @@ func (e Event) Encode() []byte {
 	return mustRLPEncode(&temp)
 }
 
+// GetField returns the value of the event field with the given name.
+//
+// False is returned if the event type does not declare a field with this name,
+// or if the event has no value for it.
+func (e Event) GetField(name string) (cadence.Value, bool) {
+	for i, field := range e.Value.EventType.Fields {
+		if field.Identifier != name {
+			continue
+		}
+
+		if i >= len(e.Value.Fields) {
+			return nil, false
+		}
+
+		return e.Value.Fields[i], true
+	}
+
+	return nil, false
+}
+
 // An AccountCreatedEvent is emitted when a transaction creates a new Flow account.
 //
 // This event contains the following fields:
@@ -73,5 +95,210 @@ type AccountCreatedEvent Event
 
 // Address returns the address of the newly-created account.
 func (evt AccountCreatedEvent) Address() Address {
-	return BytesToAddress(evt.Value.Fields[0].(cadence.Address).Bytes())
+	return CadenceToAddress(evt.Value.Fields[0].(cadence.Address))
+}
+
+// An EventType identifies an event declared by a contract.
+//
+// Its string form is "A.<address>.<contract>.<event>", for example
+// "A.1654653399040a61.FlowToken.TokensDeposited".
+type EventType struct {
+	Address      Address
+	ContractName string
+	EventName    string
+}
+
+// ParseEventType parses a contract event type identifier.
+//
+// The address may be prefixed with 0x and may omit leading zeros. Built-in event types,
+// such as "flow.AccountCreated", are not declared by a contract and cannot be parsed.
+func ParseEventType(s string) (EventType, error) {
+	segments := strings.Split(s, ".")
+	if len(segments) != 4 {
+		return EventType{}, fmt.Errorf("invalid event type %q: expected 4 segments, got %d", s, len(segments))
+	}
+
+	if segments[0] != "A" {
+		return EventType{}, fmt.Errorf("invalid event type %q: expected prefix A, got %q", s, segments[0])
+	}
+
+	address, err := parseEventTypeAddress(segments[1])
+	if err != nil {
+		return EventType{}, fmt.Errorf("invalid event type %q: %w", s, err)
+	}
+
+	for _, name := range segments[2:] {
+		if !isIdentifier(name) {
+			return EventType{}, fmt.Errorf("invalid event type %q: %q is not a valid identifier", s, name)
+		}
+	}
+
+	return EventType{
+		Address:      address,
+		ContractName: segments[2],
+		EventName:    segments[3],
+	}, nil
+}
+
+// String returns the identifier of this event type, as used in the Type field of events.
+func (t EventType) String() string {
+	return fmt.Sprintf("A.%s.%s.%s", t.Address.Hex(), t.ContractName, t.EventName)
+}
+
+func parseEventTypeAddress(s string) (Address, error) {
+	h := strings.TrimPrefix(s, "0x")
+
+	if h == "" || len(h) > 2*AddressLength {
+		return Address{}, fmt.Errorf("invalid address %q", s)
+	}
+
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid address %q", s)
+	}
+
+	return BytesToAddress(b), nil
+}
+
+// isIdentifier returns true if s is a valid Cadence identifier.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateEventAgainstSchema returns an error if the fields of an event do not match the
+// fields declared by an event schema.
+//
+// Fields must have the same names, in the same order, and each field value must conform to
+// the declared field type. Use this to detect events whose shape changed after a contract upgrade.
+func ValidateEventAgainstSchema(event Event, schema cadence.CompositeType) error {
+	if typeID := schema.ID(); typeID != "" && typeID != event.Type {
+		return fmt.Errorf("event type %s does not match schema type %s", event.Type, typeID)
+	}
+
+	fields := event.Value.EventType.Fields
+	values := event.Value.Fields
+	schemaFields := schema.CompositeFields()
+
+	if len(values) != len(fields) {
+		return fmt.Errorf("event %s has %d field values but %d field types", event.Type, len(values), len(fields))
+	}
+
+	if len(fields) != len(schemaFields) {
+		return fmt.Errorf("event %s has %d fields, schema declares %d", event.Type, len(fields), len(schemaFields))
+	}
+
+	for i, schemaField := range schemaFields {
+		if fields[i].Identifier != schemaField.Identifier {
+			return fmt.Errorf(
+				"event %s field %d is named %s, schema declares %s",
+				event.Type,
+				i,
+				fields[i].Identifier,
+				schemaField.Identifier,
+			)
+		}
+
+		if !valueConformsToType(values[i], schemaField.Type) {
+			return fmt.Errorf(
+				"event %s field %s does not conform to schema type %s",
+				event.Type,
+				schemaField.Identifier,
+				schemaField.Type.ID(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// valueConformsToType returns true if a decoded Cadence value conforms to the given type.
+//
+// Types are checked structurally because the types of decoded values are inferred from the
+// values themselves, so an empty array, for example, carries no element type.
+func valueConformsToType(value cadence.Value, typ cadence.Type) bool {
+	switch t := typ.(type) {
+	case cadence.AnyType, cadence.AnyStructType, cadence.AnyResourceType:
+		return true
+
+	case cadence.OptionalType:
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return false
+		}
+
+		return optional.Value == nil || valueConformsToType(optional.Value, t.Type)
+
+	case cadence.VariableSizedArrayType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+
+		return valuesConformToType(array.Values, t.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		array, ok := value.(cadence.Array)
+		if !ok || uint(len(array.Values)) != t.Size {
+			return false
+		}
+
+		return valuesConformToType(array.Values, t.ElementType)
+
+	case cadence.DictionaryType:
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return false
+		}
+
+		for _, pair := range dictionary.Pairs {
+			if !valueConformsToType(pair.Key, t.KeyType) || !valueConformsToType(pair.Value, t.ElementType) {
+				return false
+			}
+		}
+
+		return true
+
+	case cadence.StructType, cadence.ResourceType, cadence.EventType:
+		switch value.(type) {
+		case cadence.Struct, cadence.Resource, cadence.Event:
+			return value.Type().ID() == t.ID()
+		default:
+			return false
+		}
+
+	default:
+		switch value.(type) {
+		case cadence.Optional, cadence.Array, cadence.Dictionary:
+			return false
+		default:
+			return value.Type().ID() == t.ID()
+		}
+	}
+}
+
+func valuesConformToType(values []cadence.Value, typ cadence.Type) bool {
+	for _, value := range values {
+		if !valueConformsToType(value, typ) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -0,0 +1,231 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestEvent_GetField(t *testing.T) {
+	event := test.EventGenerator().New()
+
+	t.Run("Declared fields", func(t *testing.T) {
+		a, ok := event.GetField("a")
+		require.True(t, ok)
+		assert.Equal(t, cadence.NewInt(1), a)
+
+		b, ok := event.GetField("b")
+		require.True(t, ok)
+		assert.Equal(t, cadence.NewString("foo"), b)
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		value, ok := event.GetField("c")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+	})
+
+	t.Run("Reordered fields", func(t *testing.T) {
+		reordered := event
+		reordered.Value.EventType.Fields = []cadence.Field{
+			event.Value.EventType.Fields[1],
+			event.Value.EventType.Fields[0],
+		}
+		reordered.Value.Fields = []cadence.Value{
+			event.Value.Fields[1],
+			event.Value.Fields[0],
+		}
+
+		a, ok := reordered.GetField("a")
+		require.True(t, ok)
+		assert.Equal(t, cadence.NewInt(1), a)
+	})
+
+	t.Run("Missing value", func(t *testing.T) {
+		truncated := event
+		truncated.Value.Fields = event.Value.Fields[:1]
+
+		_, ok := truncated.GetField("b")
+		assert.False(t, ok)
+	})
+
+	t.Run("Empty event", func(t *testing.T) {
+		_, ok := flow.Event{}.GetField("a")
+		assert.False(t, ok)
+	})
+}
+
+func TestValidateEventAgainstSchema(t *testing.T) {
+	event := test.EventGenerator().New()
+
+	schema := func(fields ...cadence.Field) cadence.EventType {
+		return cadence.EventType{
+			TypeID:     event.Type,
+			Identifier: event.Value.EventType.Identifier,
+			Fields:     fields,
+		}
+	}
+
+	t.Run("Matching", func(t *testing.T) {
+		err := flow.ValidateEventAgainstSchema(event, schema(
+			cadence.Field{Identifier: "a", Type: cadence.IntType{}},
+			cadence.Field{Identifier: "b", Type: cadence.StringType{}},
+		))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Field type changed", func(t *testing.T) {
+		err := flow.ValidateEventAgainstSchema(event, schema(
+			cadence.Field{Identifier: "a", Type: cadence.UInt64Type{}},
+			cadence.Field{Identifier: "b", Type: cadence.StringType{}},
+		))
+		assert.Error(t, err)
+	})
+
+	t.Run("Field renamed", func(t *testing.T) {
+		err := flow.ValidateEventAgainstSchema(event, schema(
+			cadence.Field{Identifier: "a", Type: cadence.IntType{}},
+			cadence.Field{Identifier: "c", Type: cadence.StringType{}},
+		))
+		assert.Error(t, err)
+	})
+
+	t.Run("Field added", func(t *testing.T) {
+		err := flow.ValidateEventAgainstSchema(event, schema(
+			cadence.Field{Identifier: "a", Type: cadence.IntType{}},
+			cadence.Field{Identifier: "b", Type: cadence.StringType{}},
+			cadence.Field{Identifier: "c", Type: cadence.BoolType{}},
+		))
+		assert.Error(t, err)
+	})
+
+	t.Run("Different event type", func(t *testing.T) {
+		other := schema(
+			cadence.Field{Identifier: "a", Type: cadence.IntType{}},
+			cadence.Field{Identifier: "b", Type: cadence.StringType{}},
+		)
+		other.TypeID = "test.BarEvent"
+
+		err := flow.ValidateEventAgainstSchema(event, other)
+		assert.Error(t, err)
+	})
+
+	t.Run("Container types", func(t *testing.T) {
+		fields := []cadence.Field{
+			{Identifier: "ids", Type: cadence.VariableSizedArrayType{ElementType: cadence.UInt64Type{}}},
+			{Identifier: "owner", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+		}
+
+		event := flow.Event{
+			Type: "test.Withdrawn",
+			Value: cadence.NewEvent([]cadence.Value{
+				cadence.NewArray([]cadence.Value{}),
+				cadence.NewOptional(nil),
+			}).WithType(cadence.EventType{
+				TypeID: "test.Withdrawn",
+				Fields: []cadence.Field{
+					{Identifier: "ids", Type: cadence.VariableSizedArrayType{ElementType: cadence.AnyType{}}},
+					{Identifier: "owner", Type: cadence.OptionalType{Type: cadence.AnyType{}}},
+				},
+			}),
+		}
+
+		schema := cadence.EventType{TypeID: "test.Withdrawn", Fields: fields}
+
+		assert.NoError(t, flow.ValidateEventAgainstSchema(event, schema))
+
+		event.Value.Fields[0] = cadence.NewArray([]cadence.Value{cadence.NewString("1")})
+
+		assert.Error(t, flow.ValidateEventAgainstSchema(event, schema))
+	})
+}
+
+func TestParseEventType(t *testing.T) {
+	address := flow.HexToAddress("1654653399040a61")
+
+	t.Run("Valid", func(t *testing.T) {
+		eventType, err := flow.ParseEventType("A." + address.Hex() + ".FlowToken.TokensDeposited")
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.EventType{
+			Address:      address,
+			ContractName: "FlowToken",
+			EventName:    "TokensDeposited",
+		}, eventType)
+
+		assert.Equal(t, "A."+address.Hex()+".FlowToken.TokensDeposited", eventType.String())
+	})
+
+	t.Run("Short address", func(t *testing.T) {
+		for _, s := range []string{
+			"A.0x1654653399040a61.FlowToken.TokensDeposited",
+			"A.1654653399040a61.FlowToken.TokensDeposited",
+		} {
+			eventType, err := flow.ParseEventType(s)
+			require.NoError(t, err)
+
+			assert.Equal(t, address, eventType.Address)
+		}
+
+		eventType, err := flow.ParseEventType("A.0x1.Foo.Bar")
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.HexToAddress("01"), eventType.Address)
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		eventType := flow.EventType{
+			Address:      address,
+			ContractName: "Foo",
+			EventName:    "Bar_2",
+		}
+
+		parsed, err := flow.ParseEventType(eventType.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, eventType, parsed)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			flow.EventAccountCreated,
+			"A.1654653399040a61.FlowToken",
+			"A.1654653399040a61.FlowToken.TokensDeposited.Extra",
+			"B.1654653399040a61.FlowToken.TokensDeposited",
+			"A.xyz.FlowToken.TokensDeposited",
+			"A..FlowToken.TokensDeposited",
+			"A.0x.FlowToken.TokensDeposited",
+			"A.00000000000000000000000000000000000000001.FlowToken.TokensDeposited",
+			"A.1654653399040a61.Flow-Token.TokensDeposited",
+			"A.1654653399040a61.FlowToken.1TokensDeposited",
+			"A.1654653399040a61..TokensDeposited",
+		} {
+			_, err := flow.ParseEventType(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
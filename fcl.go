@@ -0,0 +1,114 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// fclVoucher is the transaction representation exchanged with FCL wallets.
+type fclVoucher struct {
+	Cadence      string            `json:"cadence"`
+	RefBlock     string            `json:"refBlock"`
+	ComputeLimit uint64            `json:"computeLimit"`
+	Arguments    []json.RawMessage `json:"arguments"`
+	ProposalKey  fclProposalKey    `json:"proposalKey"`
+	Payer        string            `json:"payer"`
+	Authorizers  []string          `json:"authorizers"`
+	PayloadSigs  []fclSignature    `json:"payloadSigs"`
+	EnvelopeSigs []fclSignature    `json:"envelopeSigs"`
+}
+
+type fclProposalKey struct {
+	Address     string `json:"address"`
+	KeyID       int    `json:"keyId"`
+	SequenceNum uint64 `json:"sequenceNum"`
+}
+
+type fclSignature struct {
+	Address string `json:"address"`
+	KeyID   int    `json:"keyId"`
+	Sig     string `json:"sig"`
+}
+
+// ToFCLJSON returns the JSON encoding of this transaction as an FCL voucher, the format
+// in which FCL passes transactions to wallets for signing.
+//
+// Addresses are encoded as 0x-prefixed hex strings of 8 bytes, and the reference block ID
+// and signatures as hex strings. Transactions in this SDK do not have arguments, so the
+// argument list is always empty.
+func (t *Transaction) ToFCLJSON() ([]byte, error) {
+	authorizers := make([]string, len(t.Authorizers))
+	for i, authorizer := range t.Authorizers {
+		authorizers[i] = fclAddress(authorizer)
+	}
+
+	voucher := fclVoucher{
+		Cadence:      string(t.Script),
+		RefBlock:     t.ReferenceBlockID.Hex(),
+		ComputeLimit: t.GasLimit,
+		Arguments:    []json.RawMessage{},
+		ProposalKey: fclProposalKey{
+			Address:     fclAddress(t.ProposalKey.Address),
+			KeyID:       t.ProposalKey.KeyID,
+			SequenceNum: t.ProposalKey.SequenceNumber,
+		},
+		Payer:        fclAddress(t.Payer),
+		Authorizers:  authorizers,
+		PayloadSigs:  fclSignatures(t.PayloadSignatures),
+		EnvelopeSigs: fclSignatures(t.EnvelopeSignatures),
+	}
+
+	b, err := json.Marshal(voucher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode FCL voucher: %w", err)
+	}
+
+	return b, nil
+}
+
+// fclAddress returns the 0x-prefixed hex representation of an address used by FCL,
+// which has at least 8 bytes.
+func fclAddress(address Address) string {
+	const minHexLength = 16
+
+	h := strings.TrimLeft(address.Hex(), "0")
+	if len(h) < minHexLength {
+		h = strings.Repeat("0", minHexLength-len(h)) + h
+	}
+
+	return "0x" + h
+}
+
+func fclSignatures(signatures []TransactionSignature) []fclSignature {
+	sigs := make([]fclSignature, len(signatures))
+
+	for i, sig := range signatures {
+		sigs[i] = fclSignature{
+			Address: fclAddress(sig.Address),
+			KeyID:   sig.KeyID,
+			Sig:     hex.EncodeToString(sig.Signature),
+		}
+	}
+
+	return sigs
+}
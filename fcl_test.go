@@ -0,0 +1,82 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestTransaction_ToFCLJSON(t *testing.T) {
+	proposer := flow.HexToAddress("f8d6e0586b0a20c7")
+	authorizer := flow.HexToAddress("01cf0e2f2f715450")
+
+	refBlockID, _ := hex.DecodeString("dc8ebfa5e9d2a68e4a8e0a4c0b5dd2eba4b34bb6f0d9a6d7e4bd0be0e5b1a4cd")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte("transaction { execute { log(\"hello\") } }")).
+		SetReferenceBlockID(flow.BytesToID(refBlockID)).
+		SetGasLimit(100).
+		SetProposalKey(proposer, 1, 42).
+		SetPayer(proposer).
+		AddAuthorizer(authorizer)
+
+	tx.AddPayloadSignature(authorizer, 0, []byte{0xab, 0xcd})
+	tx.AddEnvelopeSignature(proposer, 1, []byte{0x01, 0x02})
+
+	b, err := tx.ToFCLJSON()
+	require.NoError(t, err)
+
+	expected := `{
+		"cadence": "transaction { execute { log(\"hello\") } }",
+		"refBlock": "dc8ebfa5e9d2a68e4a8e0a4c0b5dd2eba4b34bb6f0d9a6d7e4bd0be0e5b1a4cd",
+		"computeLimit": 100,
+		"arguments": [],
+		"proposalKey": {
+			"address": "0xf8d6e0586b0a20c7",
+			"keyId": 1,
+			"sequenceNum": 42
+		},
+		"payer": "0xf8d6e0586b0a20c7",
+		"authorizers": ["0x01cf0e2f2f715450"],
+		"payloadSigs": [
+			{"address": "0x01cf0e2f2f715450", "keyId": 0, "sig": "abcd"}
+		],
+		"envelopeSigs": [
+			{"address": "0xf8d6e0586b0a20c7", "keyId": 1, "sig": "0102"}
+		]
+	}`
+
+	assert.JSONEq(t, expected, string(b))
+
+	t.Run("Unsigned", func(t *testing.T) {
+		b, err := flow.NewTransaction().SetPayer(flow.HexToAddress("01")).ToFCLJSON()
+		require.NoError(t, err)
+
+		assert.Contains(t, string(b), `"payer":"0x0000000000000001"`)
+		assert.Contains(t, string(b), `"authorizers":[]`)
+		assert.Contains(t, string(b), `"payloadSigs":[]`)
+		assert.Contains(t, string(b), `"envelopeSigs":[]`)
+	})
+}
@@ -0,0 +1,100 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"math"
+	"math/bits"
+)
+
+// UFix64Factor is the scaling factor of a Cadence UFix64 value.
+//
+// A UFix64 value of 1.0 is represented by the integer 100000000.
+const UFix64Factor uint64 = 100000000
+
+// FeeParameters are the transaction fee parameters stored in the FlowFees contract.
+//
+// All values are UFix64 fixed-point numbers, scaled by UFix64Factor.
+type FeeParameters struct {
+	SurgeFactor           uint64
+	InclusionEffortFactor uint64
+	ExecutionEffortFactor uint64
+}
+
+// InclusionEffort returns the inclusion effort of this transaction, as a UFix64 fixed-point
+// number scaled by UFix64Factor.
+//
+// The fee model allows the inclusion effort to depend on the byte size and number of
+// signatures of a transaction, but the protocol currently charges every transaction a
+// fixed inclusion effort of 1.0, which is the value passed to FlowFees.computeFees.
+func (t *Transaction) InclusionEffort() uint64 {
+	return UFix64Factor
+}
+
+// ComputeTransactionFee returns the fee charged for a transaction with the given inclusion
+// and execution effort.
+//
+// The fee is computed in the same way as FlowFees.computeFees:
+//
+//	surgeFactor * (inclusionEffort * inclusionEffortFactor + executionEffort * executionEffortFactor)
+//
+// All arguments and the result are UFix64 fixed-point numbers, scaled by UFix64Factor.
+// Each multiplication truncates to 8 decimal places, as UFix64 multiplication does in Cadence.
+// A result that does not fit into a UFix64 is clamped to the maximum UFix64 value.
+func ComputeTransactionFee(inclusionEffort, executionEffort uint64, params FeeParameters) uint64 {
+	inclusionFee := mulUFix64(inclusionEffort, params.InclusionEffortFactor)
+	executionFee := mulUFix64(executionEffort, params.ExecutionEffortFactor)
+
+	effortFee, carry := bits.Add64(inclusionFee, executionFee, 0)
+	if carry != 0 {
+		return math.MaxUint64
+	}
+
+	return mulUFix64(params.SurgeFactor, effortFee)
+}
+
+// EstimateTransactionFee returns the maximum fee that can be charged for a transaction,
+// as a UFix64 fixed-point number scaled by UFix64Factor.
+//
+// The execution effort of a transaction is only known once it is executed, so the estimate
+// assumes that the transaction uses its full gas limit, with one unit of gas counted as an
+// execution effort of 1.0. The inclusion effort is given by Transaction.InclusionEffort.
+//
+// The fee parameters are network-dependent: the surge factor and the effort factors are set
+// in the FlowFees contract of each network and can change at any time, so they should be
+// fetched shortly before estimating, for example with client.GetFeeParameters.
+func EstimateTransactionFee(tx *Transaction, params FeeParameters) uint64 {
+	hi, executionEffort := bits.Mul64(tx.GasLimit, UFix64Factor)
+	if hi != 0 {
+		return math.MaxUint64
+	}
+
+	return ComputeTransactionFee(tx.InclusionEffort(), executionEffort, params)
+}
+
+// mulUFix64 multiplies two UFix64 fixed-point numbers, truncating the result.
+func mulUFix64(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= UFix64Factor {
+		return math.MaxUint64
+	}
+
+	quo, _ := bits.Div64(hi, lo, UFix64Factor)
+	return quo
+}
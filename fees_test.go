@@ -0,0 +1,170 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestComputeTransactionFee(t *testing.T) {
+	type testcase struct {
+		name            string
+		inclusionEffort uint64
+		executionEffort uint64
+		params          flow.FeeParameters
+		expected        uint64
+	}
+
+	params := flow.FeeParameters{
+		SurgeFactor:           100000000, // 1.0
+		InclusionEffortFactor: 100,       // 0.000001
+		ExecutionEffortFactor: 4000,      // 0.00004
+	}
+
+	cases := []testcase{
+		{
+			name:            "Zero effort",
+			inclusionEffort: 0,
+			executionEffort: 0,
+			params:          params,
+			expected:        0,
+		},
+		{
+			name:            "Inclusion only",
+			inclusionEffort: 100000000, // 1.0
+			executionEffort: 0,
+			params:          params,
+			expected:        100, // 0.000001
+		},
+		{
+			name:            "Inclusion and execution",
+			inclusionEffort: 100000000,  // 1.0
+			executionEffort: 1000000000, // 10.0
+			params:          params,
+			expected:        40100, // 0.000401
+		},
+		{
+			name:            "Surge factor",
+			inclusionEffort: 100000000,  // 1.0
+			executionEffort: 1000000000, // 10.0
+			params: flow.FeeParameters{
+				SurgeFactor:           250000000, // 2.5
+				InclusionEffortFactor: params.InclusionEffortFactor,
+				ExecutionEffortFactor: params.ExecutionEffortFactor,
+			},
+			expected: 100250, // 0.0010025
+		},
+		{
+			name:            "Truncation",
+			inclusionEffort: 1, // 0.00000001
+			executionEffort: 1, // 0.00000001
+			params:          params,
+			expected:        0,
+		},
+		{
+			name:            "Overflow",
+			inclusionEffort: math.MaxUint64,
+			executionEffort: math.MaxUint64,
+			params: flow.FeeParameters{
+				SurgeFactor:           math.MaxUint64,
+				InclusionEffortFactor: math.MaxUint64,
+				ExecutionEffortFactor: math.MaxUint64,
+			},
+			expected: math.MaxUint64,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fee := flow.ComputeTransactionFee(c.inclusionEffort, c.executionEffort, c.params)
+			assert.Equal(t, c.expected, fee)
+		})
+	}
+}
+
+func TestTransaction_InclusionEffort(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, flow.UFix64Factor, flow.NewTransaction().InclusionEffort())
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+		tx.SetScript(make([]byte, 10000))
+
+		assert.NotEmpty(t, tx.PayloadSignatures)
+		assert.NotEmpty(t, tx.EnvelopeSignatures)
+
+		assert.Equal(t, flow.UFix64Factor, tx.InclusionEffort())
+	})
+
+	t.Run("Fee", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		fee := flow.ComputeTransactionFee(tx.InclusionEffort(), 0, flow.FeeParameters{
+			SurgeFactor:           100000000, // 1.0
+			InclusionEffortFactor: 100,       // 0.000001
+			ExecutionEffortFactor: 0,
+		})
+
+		assert.Equal(t, uint64(100), fee)
+	})
+}
+
+func TestEstimateTransactionFee(t *testing.T) {
+	params := flow.FeeParameters{
+		SurgeFactor:           100000000, // 1.0
+		InclusionEffortFactor: 100,       // 0.000001
+		ExecutionEffortFactor: 4000,      // 0.00004
+	}
+
+	t.Run("Gas limit", func(t *testing.T) {
+		tx := flow.NewTransaction().SetGasLimit(10)
+
+		// 1.0 * 0.000001 + 10.0 * 0.00004
+		assert.Equal(t, uint64(40100), flow.EstimateTransactionFee(tx, params))
+	})
+
+	t.Run("Surge factor", func(t *testing.T) {
+		tx := flow.NewTransaction().SetGasLimit(10)
+
+		surged := params
+		surged.SurgeFactor = 250000000 // 2.5
+
+		assert.Equal(t, uint64(100250), flow.EstimateTransactionFee(tx, surged))
+	})
+
+	t.Run("Maximum gas limit", func(t *testing.T) {
+		tx := flow.NewTransaction().SetGasLimit(flow.DefaultMaxGasLimit)
+
+		// 1.0 * 0.000001 + 9999.0 * 0.00004
+		assert.Equal(t, uint64(39996100), flow.EstimateTransactionFee(tx, params))
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		tx := flow.NewTransaction().SetGasLimit(math.MaxUint64)
+
+		assert.Equal(t, uint64(math.MaxUint64), flow.EstimateTransactionFee(tx, params))
+	})
+}
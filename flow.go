@@ -58,13 +58,41 @@ func HashToID(hash []byte) Identifier {
 	return BytesToID(hash)
 }
 
+// A ChainID is a unique identifier for a specific Flow network instance.
+type ChainID string
+
+const (
+	// Mainnet is the chain ID for the mainnet chain.
+	Mainnet ChainID = "flow-mainnet"
+	// Testnet is the chain ID for the testnet chain.
+	Testnet ChainID = "flow-testnet"
+	// Emulator is the chain ID for the emulated chain.
+	Emulator ChainID = "flow-emulator"
+	// Sandboxnet is the chain ID for the sandbox network chain.
+	Sandboxnet ChainID = "flow-sandboxnet"
+)
+
+// String returns the string representation of this chain ID.
+func (id ChainID) String() string {
+	return string(id)
+}
+
 // DefaultHasher is the default hasher used by Flow.
+//
+// DefaultHasher is not safe for concurrent use.
 var DefaultHasher crypto.Hasher
 
 func init() {
 	DefaultHasher = crypto.NewSHA3_256()
 }
 
+// canonicalHash returns the SHA3-256 hash of data.
+//
+// A new hasher is used for each call, so that IDs can be computed concurrently.
+func canonicalHash(data []byte) crypto.Hash {
+	return crypto.NewSHA3_256().ComputeHash(data)
+}
+
 func rlpEncode(v interface{}) ([]byte, error) {
 	return rlp.EncodeToBytes(v)
 }
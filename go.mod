@@ -3,7 +3,9 @@ module github.com/onflow/flow-go-sdk
 go 1.13
 
 require (
+	github.com/antlr/antlr4 v0.0.0-20191217191749-ff67971f8580
 	github.com/ethereum/go-ethereum v1.9.9
+	github.com/golang/protobuf v1.3.5
 	github.com/google/go-cmp v0.4.0 // indirect
 	github.com/lithammer/dedent v1.1.0
 	github.com/magiconair/properties v1.8.1
@@ -12,7 +14,9 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/objx v0.1.1 // indirect
 	github.com/stretchr/testify v1.5.1
+	github.com/tyler-smith/go-bip39 v1.0.2
 	golang.org/x/crypto v0.0.0-20200117160349-530e935923ad
+	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55
 	google.golang.org/grpc v1.28.0
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 	gopkg.in/yaml.v2 v2.2.4 // indirect
@@ -0,0 +1,142 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// A SyntaxError is a single syntax error found in a Cadence script.
+type SyntaxError struct {
+	// Line is the line number of the error, starting at 1.
+	Line int
+	// Column is the column number of the error, starting at 0.
+	Column int
+	// Message describes the error.
+	Message string
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// SyntaxErrors is the list of syntax errors found in a Cadence script.
+type SyntaxErrors []SyntaxError
+
+func (e SyntaxErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("invalid script syntax:\n%s", strings.Join(messages, "\n"))
+}
+
+// CheckScriptSyntax parses a Cadence script and returns an error if it is not syntactically valid.
+//
+// The returned error is of type SyntaxErrors and contains the line and column of each
+// problem. The script is only parsed, not type checked, so a nil error does not guarantee
+// that the script will execute successfully.
+func CheckScriptSyntax(script []byte) error {
+	_, err := parseScript(script)
+	return err
+}
+
+// NormalizeScript returns a canonical form of a Cadence script, so that scripts that only
+// differ in comments and whitespace normalize to the same bytes.
+//
+// Comments are removed and tokens are separated by a single space, or by a single line
+// break if the original tokens were on different lines, because line breaks can end
+// statements in Cadence. The normalized form is intended for comparison, not execution.
+//
+// An error of type SyntaxErrors is returned if the script is not syntactically valid.
+func NormalizeScript(script []byte) ([]byte, error) {
+	_, err := parseScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	lexer := parser.NewCadenceLexer(antlr.NewInputStream(string(script)))
+	lexer.RemoveErrorListeners()
+
+	var (
+		b         strings.Builder
+		lineBreak bool
+	)
+
+	for {
+		token := lexer.NextToken()
+		if token.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+
+		// whitespace, line terminators and comments are on the hidden channel
+		if token.GetChannel() == antlr.TokenHiddenChannel {
+			lineBreak = lineBreak || strings.ContainsAny(token.GetText(), "\r\n\u2028\u2029")
+			continue
+		}
+
+		if b.Len() > 0 {
+			if lineBreak {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+
+		b.WriteString(token.GetText())
+
+		lineBreak = false
+	}
+
+	return []byte(b.String()), nil
+}
+
+// parseScript parses a Cadence script, converting parser errors to SyntaxErrors.
+func parseScript(script []byte) (*ast.Program, error) {
+	program, _, err := parser.ParseProgram(string(script))
+	if err == nil {
+		return program, nil
+	}
+
+	parserErr, ok := err.(parser.Error)
+	if !ok {
+		return nil, SyntaxErrors{{Message: err.Error()}}
+	}
+
+	syntaxErrors := make(SyntaxErrors, len(parserErr.Errors))
+	for i, childErr := range parserErr.Errors {
+		syntaxErr := SyntaxError{Message: childErr.Error()}
+
+		if positioned, ok := childErr.(ast.HasPosition); ok {
+			pos := positioned.StartPosition()
+			syntaxErr.Line = pos.Line
+			syntaxErr.Column = pos.Column
+		}
+
+		syntaxErrors[i] = syntaxErr
+	}
+
+	return nil, syntaxErrors
+}
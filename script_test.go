@@ -0,0 +1,110 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestCheckScriptSyntax(t *testing.T) {
+	t.Run("Valid script", func(t *testing.T) {
+		err := flow.CheckScriptSyntax(test.ScriptHelloWorld)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Type errors are ignored", func(t *testing.T) {
+		script := []byte(`pub fun main(): Int { return undefinedValue }`)
+
+		err := flow.CheckScriptSyntax(script)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid script", func(t *testing.T) {
+		script := []byte("transaction {\n  execute {\n    log(\"Hello, World!\"\n  }\n}")
+
+		err := flow.CheckScriptSyntax(script)
+		require.Error(t, err)
+
+		syntaxErrors, ok := err.(flow.SyntaxErrors)
+		require.True(t, ok)
+		require.NotEmpty(t, syntaxErrors)
+
+		assert.Equal(t, 4, syntaxErrors[0].Line)
+		assert.NotEmpty(t, syntaxErrors[0].Message)
+	})
+}
+
+func TestNormalizeScript(t *testing.T) {
+	t.Run("Whitespace and comments", func(t *testing.T) {
+		a := []byte(`
+			// Logs a greeting
+			transaction {
+				execute {
+					log("Hello, World!") // the greeting
+				}
+			}
+		`)
+
+		b := []byte("transaction{\n\n  /* greet */ execute{\n\tlog( \"Hello, World!\" )\n}\n}")
+
+		normalizedA, err := flow.NormalizeScript(a)
+		require.NoError(t, err)
+
+		normalizedB, err := flow.NormalizeScript(b)
+		require.NoError(t, err)
+
+		assert.Equal(t, "transaction {\nexecute {\nlog ( \"Hello, World!\" )\n}\n}", string(normalizedA))
+		assert.Equal(t, normalizedA, normalizedB)
+	})
+
+	t.Run("String literals", func(t *testing.T) {
+		a, err := flow.NormalizeScript([]byte(`transaction { execute { log("a  b") } }`))
+		require.NoError(t, err)
+
+		b, err := flow.NormalizeScript([]byte(`transaction { execute { log("a b") } }`))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("Line breaks", func(t *testing.T) {
+		a, err := flow.NormalizeScript([]byte("pub fun main() {\n  let a = 1\n  let b = 2\n}"))
+		require.NoError(t, err)
+
+		b, err := flow.NormalizeScript([]byte("pub fun main() {\n\n  let a = 1\r\n\n\n  let b = 2\n}"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "pub fun main ( ) {\nlet a = 1\nlet b = 2\n}", string(a))
+		assert.Equal(t, a, b)
+	})
+
+	t.Run("Invalid script", func(t *testing.T) {
+		_, err := flow.NormalizeScript([]byte(`transaction { execute { log("Hello" }`))
+		require.Error(t, err)
+
+		_, ok := err.(flow.SyntaxErrors)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"fmt"
+)
+
+// SporkInfo describes a spork of a Flow network: the range of block heights it
+// contains and the access node that serves them.
+type SporkInfo struct {
+	// Name is the name of the spork, e.g. "mainnet-1".
+	Name string
+	// AccessNode is the address of an access node that serves the spork.
+	AccessNode string
+	// RootHeight is the height of the first block of the spork.
+	RootHeight uint64
+	// EndHeight is the height of the last block of the spork,
+	// or zero if the spork is still running.
+	EndHeight uint64
+}
+
+// ContainsHeight returns true if the block at the given height belongs to this spork.
+func (s SporkInfo) ContainsHeight(height uint64) bool {
+	if height < s.RootHeight {
+		return false
+	}
+
+	return s.EndHeight == 0 || height <= s.EndHeight
+}
+
+// SporkForHeight returns the spork in the list that contains the block at the given height.
+//
+// The sporks may be listed in any order. An error is returned if no spork contains the height.
+func SporkForHeight(height uint64, sporks []SporkInfo) (SporkInfo, error) {
+	for _, spork := range sporks {
+		if spork.ContainsHeight(height) {
+			return spork, nil
+		}
+	}
+
+	return SporkInfo{}, fmt.Errorf("no spork contains block height %d", height)
+}
@@ -0,0 +1,71 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestSporkForHeight(t *testing.T) {
+	sporks := []flow.SporkInfo{
+		{Name: "spork-3", AccessNode: "access-3:9000", RootHeight: 2000},
+		{Name: "spork-1", AccessNode: "access-1:9000", RootHeight: 100, EndHeight: 999},
+		{Name: "spork-2", AccessNode: "access-2:9000", RootHeight: 1000, EndHeight: 1999},
+	}
+
+	tests := []struct {
+		height uint64
+		spork  string
+	}{
+		{100, "spork-1"},
+		{500, "spork-1"},
+		{999, "spork-1"},
+		{1000, "spork-2"},
+		{1999, "spork-2"},
+		{2000, "spork-3"},
+		{1000000, "spork-3"},
+	}
+
+	for _, tt := range tests {
+		spork, err := flow.SporkForHeight(tt.height, sporks)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.spork, spork.Name, "height %d", tt.height)
+	}
+
+	t.Run("Before first spork", func(t *testing.T) {
+		_, err := flow.SporkForHeight(99, sporks)
+		assert.Error(t, err)
+	})
+
+	t.Run("Gap between sporks", func(t *testing.T) {
+		_, err := flow.SporkForHeight(1500, sporks[:2])
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty list", func(t *testing.T) {
+		_, err := flow.SporkForHeight(0, nil)
+		assert.Error(t, err)
+	})
+}
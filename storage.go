@@ -0,0 +1,47 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// EstimateStorageSize returns an estimate of the number of bytes a Cadence value
+// occupies when written to account storage.
+//
+// The estimate is the size of the JSON-Cadence encoding of the value. This encoding
+// includes the type of every nested value and is larger than the binary encoding used
+// for storage, so the estimate is conservative: it is expected to overestimate the
+// actual storage used, but not to underestimate it. Storage used for the path or key
+// under which the value is stored is not included.
+func EstimateStorageSize(v cadence.Value) (uint64, error) {
+	if v == nil {
+		return 0, errors.New("cannot estimate storage size of nil value")
+	}
+
+	b, err := jsoncdc.Encode(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(b)), nil
+}
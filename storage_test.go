@@ -0,0 +1,85 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestEstimateStorageSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value cadence.Value
+		size  uint64
+	}{
+		{
+			name:  "Int",
+			value: cadence.NewInt(42),
+			size:  28,
+		},
+		{
+			name:  "String",
+			value: cadence.NewString("foo"),
+			size:  32,
+		},
+		{
+			name:  "Bool",
+			value: cadence.NewBool(true),
+			size:  29,
+		},
+		{
+			name: "Array",
+			value: cadence.NewArray([]cadence.Value{
+				cadence.NewUInt8(1),
+				cadence.NewUInt8(2),
+			}),
+			size: 85,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, err := flow.EstimateStorageSize(tt.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.size, size)
+		})
+	}
+
+	t.Run("Grows with content", func(t *testing.T) {
+		small, err := flow.EstimateStorageSize(cadence.NewString("foo"))
+		require.NoError(t, err)
+
+		large, err := flow.EstimateStorageSize(cadence.NewString("foobarbaz"))
+		require.NoError(t, err)
+
+		assert.Equal(t, small+6, large)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		_, err := flow.EstimateStorageSize(nil)
+		assert.Error(t, err)
+	})
+}
@@ -20,17 +20,63 @@ package templates
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 )
 
+type createAccountOptions struct {
+	rejectDuplicateKeys bool
+}
+
+// A CreateAccountOption configures the validation done by CreateAccount.
+type CreateAccountOption func(*createAccountOptions)
+
+// RejectDuplicateKeys makes CreateAccount return an error if the same public key
+// is given more than once.
+func RejectDuplicateKeys() CreateAccountOption {
+	return func(o *createAccountOptions) {
+		o.rejectDuplicateKeys = true
+	}
+}
+
 // CreateAccount generates a script that creates a new account.
-func CreateAccount(accountKeys []*flow.AccountKey, code []byte) ([]byte, error) {
+//
+// The keys and code are embedded in the script, as transactions in this version of the
+// protocol cannot carry arguments. An error is returned if a key does not have both a
+// signature algorithm and a hash algorithm set.
+func CreateAccount(accountKeys []*flow.AccountKey, code []byte, opts ...CreateAccountOption) ([]byte, error) {
+	var options createAccountOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.rejectDuplicateKeys {
+		duplicates, err := flow.DetectDuplicateKeys(accountKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(duplicates) > 0 {
+			return nil, fmt.Errorf("duplicate public keys at indices %v", duplicates)
+		}
+	}
+
 	publicKeys := make([][]byte, len(accountKeys))
 
 	for i, accountKey := range accountKeys {
+		if accountKey.SigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("key %d has no signature algorithm", i)
+		}
+
+		if accountKey.HashAlgo == crypto.UnknownHashAlgorithm {
+			return nil, fmt.Errorf("key %d has no hash algorithm", i)
+		}
+
 		publicKeys[i] = accountKey.Encode()
 	}
 
@@ -51,6 +97,66 @@ func CreateAccount(accountKeys []*flow.AccountKey, code []byte) ([]byte, error)
 	return []byte(script), nil
 }
 
+// CreateAccountWithKeyWeights generates a transaction that creates a new account with the given
+// public keys, paired by index with the given weights and hash algorithms.
+//
+// The transaction is paid for by the payer account; the proposal key, reference block
+// and signatures must be added by the caller.
+//
+// An error is returned if the lengths of the arguments do not match, if a weight is not
+// between 0 and flow.AccountKeyWeightThreshold, or if a key is incompatible with its hash algorithm.
+func CreateAccountWithKeyWeights(
+	keys []crypto.PublicKey,
+	weights []int,
+	hashAlgos []crypto.HashAlgorithm,
+	payer flow.Address,
+) (*flow.Transaction, error) {
+	if len(weights) != len(keys) || len(hashAlgos) != len(keys) {
+		return nil, fmt.Errorf(
+			"mismatched argument lengths: %d keys, %d weights, %d hash algorithms",
+			len(keys),
+			len(weights),
+			len(hashAlgos),
+		)
+	}
+
+	accountKeys := make([]*flow.AccountKey, len(keys))
+
+	for i, key := range keys {
+		weight := weights[i]
+		if weight < 0 || weight > flow.AccountKeyWeightThreshold {
+			return nil, fmt.Errorf(
+				"weight %d of key %d is not between 0 and %d",
+				weight,
+				i,
+				flow.AccountKeyWeightThreshold,
+			)
+		}
+
+		accountKey := flow.NewAccountKey().
+			SetPublicKey(key).
+			SetSigAlgo(key.Algorithm()).
+			SetHashAlgo(hashAlgos[i]).
+			SetWeight(weight)
+
+		err := accountKey.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("key %d is invalid: %w", i, err)
+		}
+
+		accountKeys[i] = accountKey
+	}
+
+	script, err := CreateAccount(accountKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(script).
+		SetPayer(payer), nil
+}
+
 // UpdateAccountCode generates a script that updates the code associated with an account.
 func UpdateAccountCode(code []byte) []byte {
 	return []byte(fmt.Sprintf(
@@ -95,6 +201,58 @@ func RemoveAccountKey(id int) []byte {
 	return []byte(script)
 }
 
+// AddAccountKeyTransaction generates a transaction that adds a key to the account at the
+// given address, with that account as its only authorizer.
+//
+// The key is embedded in the script with its signature algorithm, hash algorithm and weight.
+// The reference block, proposal key and payer must be set by the caller.
+//
+// An error is returned if the weight of the key is not between 0 and
+// flow.AccountKeyWeightThreshold, or if the key algorithms are unset or incompatible.
+func AddAccountKeyTransaction(address flow.Address, accountKey *flow.AccountKey) (*flow.Transaction, error) {
+	if accountKey.Weight < 0 || accountKey.Weight > flow.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"weight %d is not between 0 and %d",
+			accountKey.Weight,
+			flow.AccountKeyWeightThreshold,
+		)
+	}
+
+	if accountKey.SigAlgo == crypto.UnknownSignatureAlgorithm || accountKey.HashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, errors.New("key algorithms are not set")
+	}
+
+	err := accountKey.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := AddAccountKey(accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransaction().
+		SetScript(script).
+		AddAuthorizer(address), nil
+}
+
+// RemoveAccountKeyTransaction generates a transaction that removes the key with the given
+// index from the account at the given address, with that account as its only authorizer.
+//
+// The reference block, proposal key and payer must be set by the caller.
+//
+// An error is returned if the index is not a valid UInt32 key index.
+func RemoveAccountKeyTransaction(address flow.Address, keyIndex int) (*flow.Transaction, error) {
+	if keyIndex < 0 || uint64(keyIndex) > math.MaxUint32 {
+		return nil, fmt.Errorf("key index %d is out of range", keyIndex)
+	}
+
+	return flow.NewTransaction().
+		SetScript(RemoveAccountKey(keyIndex)).
+		AddAuthorizer(address), nil
+}
+
 // languageEncodeBytes converts a byte slice to a comma-separated list of uint8 integers.
 func languageEncodeBytes(b []byte) string {
 	if len(b) == 0 {
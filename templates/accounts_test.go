@@ -19,12 +19,17 @@
 package templates_test
 
 import (
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/lithammer/dedent"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/templates"
 	"github.com/onflow/flow-go-sdk/test"
 )
@@ -67,6 +72,116 @@ func TestCreateAccount(t *testing.T) {
 			dedent.Dedent(string(script)),
 		)
 	})
+
+	t.Run("Missing signature algorithm", func(t *testing.T) {
+		key := *accountKey
+		key.SigAlgo = crypto.UnknownSignatureAlgorithm
+
+		script, err := templates.CreateAccount([]*flow.AccountKey{accountKey, &key}, nil)
+		assert.Error(t, err)
+		assert.Nil(t, script)
+	})
+
+	t.Run("Missing hash algorithm", func(t *testing.T) {
+		key := *accountKey
+		key.HashAlgo = crypto.UnknownHashAlgorithm
+
+		script, err := templates.CreateAccount([]*flow.AccountKey{&key}, nil)
+		assert.Error(t, err)
+		assert.Nil(t, script)
+	})
+
+	t.Run("Duplicate keys", func(t *testing.T) {
+		keys := []*flow.AccountKey{accountKey, accountKey}
+
+		_, err := templates.CreateAccount(keys, nil)
+		assert.NoError(t, err)
+
+		script, err := templates.CreateAccount(keys, nil, templates.RejectDuplicateKeys())
+		assert.Error(t, err)
+		assert.Nil(t, script)
+	})
+}
+
+func TestCreateAccountWithKeyWeights(t *testing.T) {
+	accountKeys := test.AccountKeyGenerator()
+
+	masterKey := accountKeys.New()
+	operationalKeyA := accountKeys.New()
+	operationalKeyB := accountKeys.New()
+
+	keys := []crypto.PublicKey{
+		masterKey.PublicKey,
+		operationalKeyA.PublicKey,
+		operationalKeyB.PublicKey,
+	}
+
+	payer := test.AddressGenerator().New()
+
+	t.Run("Master and operational keys", func(t *testing.T) {
+		tx, err := templates.CreateAccountWithKeyWeights(
+			keys,
+			[]int{1000, 100, 100},
+			[]crypto.HashAlgorithm{crypto.SHA3_256, crypto.SHA2_256, crypto.SHA3_256},
+			payer,
+		)
+		require.NoError(t, err)
+
+		expectedKeys := []*flow.AccountKey{
+			flow.NewAccountKey().
+				SetPublicKey(masterKey.PublicKey).
+				SetSigAlgo(masterKey.PublicKey.Algorithm()).
+				SetHashAlgo(crypto.SHA3_256).
+				SetWeight(1000),
+			flow.NewAccountKey().
+				SetPublicKey(operationalKeyA.PublicKey).
+				SetSigAlgo(operationalKeyA.PublicKey.Algorithm()).
+				SetHashAlgo(crypto.SHA2_256).
+				SetWeight(100),
+			flow.NewAccountKey().
+				SetPublicKey(operationalKeyB.PublicKey).
+				SetSigAlgo(operationalKeyB.PublicKey.Algorithm()).
+				SetHashAlgo(crypto.SHA3_256).
+				SetWeight(100),
+		}
+
+		expectedScript, err := templates.CreateAccount(expectedKeys, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedScript, tx.Script)
+		assert.Equal(t, payer, tx.Payer)
+		assert.Empty(t, tx.Authorizers)
+	})
+
+	t.Run("Mismatched lengths", func(t *testing.T) {
+		_, err := templates.CreateAccountWithKeyWeights(
+			keys,
+			[]int{1000, 100},
+			[]crypto.HashAlgorithm{crypto.SHA3_256, crypto.SHA3_256, crypto.SHA3_256},
+			payer,
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("Weight out of range", func(t *testing.T) {
+		_, err := templates.CreateAccountWithKeyWeights(
+			keys,
+			[]int{1001, 100, 100},
+			[]crypto.HashAlgorithm{crypto.SHA3_256, crypto.SHA3_256, crypto.SHA3_256},
+			payer,
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid hash algorithm", func(t *testing.T) {
+		_, err := templates.CreateAccountWithKeyWeights(
+			keys,
+			[]int{1000, 100, 100},
+			[]crypto.HashAlgorithm{crypto.SHA3_256, crypto.SHA3_384, crypto.SHA3_256},
+			payer,
+		)
+		assert.Error(t, err)
+	})
 }
 
 func TestUpdateAccountCode(t *testing.T) {
@@ -85,3 +200,91 @@ func TestUpdateAccountCode(t *testing.T) {
 		dedent.Dedent(string(script)),
 	)
 }
+
+func TestAddAccountKeyTransaction(t *testing.T) {
+	address := test.AddressGenerator().New()
+
+	accountKey := test.AccountKeyGenerator().New().SetWeight(500)
+
+	t.Run("Valid key", func(t *testing.T) {
+		tx, err := templates.AddAccountKeyTransaction(address, accountKey)
+		require.NoError(t, err)
+
+		expectedScript, err := templates.AddAccountKey(accountKey)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedScript, tx.Script)
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+
+		// the key embedded in the script decodes with its weight and algorithms
+		script := string(tx.Script)
+		start := strings.Index(script, "addPublicKey([") + len("addPublicKey([")
+		end := strings.Index(script[start:], "])") + start
+
+		fields := strings.Split(script[start:end], ",")
+		encoded := make([]byte, len(fields))
+
+		for i, field := range fields {
+			b, err := strconv.ParseUint(field, 10, 8)
+			require.NoError(t, err)
+			encoded[i] = byte(b)
+		}
+
+		decoded, err := flow.DecodeAccountKey(encoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, accountKey.PublicKey.Encode(), decoded.PublicKey.Encode())
+		assert.Equal(t, accountKey.SigAlgo, decoded.SigAlgo)
+		assert.Equal(t, accountKey.HashAlgo, decoded.HashAlgo)
+		assert.Equal(t, 500, decoded.Weight)
+	})
+
+	t.Run("Weight out of range", func(t *testing.T) {
+		key := *accountKey
+		key.Weight = flow.AccountKeyWeightThreshold + 1
+
+		_, err := templates.AddAccountKeyTransaction(address, &key)
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing hash algorithm", func(t *testing.T) {
+		key := *accountKey
+		key.HashAlgo = crypto.UnknownHashAlgorithm
+
+		_, err := templates.AddAccountKeyTransaction(address, &key)
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveAccountKeyTransaction(t *testing.T) {
+	address := test.AddressGenerator().New()
+
+	t.Run("Valid index", func(t *testing.T) {
+		tx, err := templates.RemoveAccountKeyTransaction(address, 3)
+		require.NoError(t, err)
+
+		expectedScript := `
+          transaction {
+            prepare(signer: AuthAccount) {
+              signer.removePublicKey(3)
+            }
+          }
+        `
+
+		assert.Equal(t,
+			dedent.Dedent(expectedScript),
+			dedent.Dedent(string(tx.Script)),
+		)
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+	})
+
+	t.Run("Negative index", func(t *testing.T) {
+		_, err := templates.RemoveAccountKeyTransaction(address, -1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Index above UInt32", func(t *testing.T) {
+		_, err := templates.RemoveAccountKeyTransaction(address, math.MaxUint32+1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,139 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A Contract is the code to be deployed to a single account.
+type Contract struct {
+	// Name is used to identify the contract in error messages.
+	Name string
+	// Account is the address of the account that the code is deployed to.
+	Account flow.Address
+	// Code is the Cadence source code of the contract.
+	Code []byte
+}
+
+// DeployContracts generates a list of transactions that deploy the given contracts
+// in dependency order.
+//
+// A contract depends on another contract in the list if it imports from that contract's
+// account. Imports from accounts that are not in the list are assumed to already be deployed.
+// Contracts with no dependency between them keep their relative order.
+//
+// Each transaction updates the code of a single account and has that account as its
+// only authorizer. The reference block, proposal key and payer must be set by the caller.
+//
+// This function returns an error if a contract cannot be parsed, if two contracts
+// are deployed to the same account, or if the contracts have a cyclic dependency.
+func DeployContracts(contracts []Contract) ([]*flow.Transaction, error) {
+	ordered, err := sortContracts(contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*flow.Transaction, len(ordered))
+	for i, contract := range ordered {
+		txs[i] = flow.NewTransaction().
+			SetScript(UpdateAccountCode(contract.Code)).
+			AddAuthorizer(contract.Account)
+	}
+
+	return txs, nil
+}
+
+// sortContracts topologically sorts contracts by their import dependencies.
+func sortContracts(contracts []Contract) ([]Contract, error) {
+	indices := make(map[flow.Address]int, len(contracts))
+	for i, contract := range contracts {
+		if j, exists := indices[contract.Account]; exists {
+			return nil, fmt.Errorf(
+				"contracts %s and %s are both deployed to account %s",
+				contracts[j].Name,
+				contract.Name,
+				contract.Account,
+			)
+		}
+
+		indices[contract.Account] = i
+	}
+
+	// dependencies[i] holds the indices of the contracts that contract i imports from
+	dependencies := make([][]int, len(contracts))
+
+	for i, contract := range contracts {
+		imports, err := flow.ParseContractImports(contract.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse contract %s: %w", contract.Name, err)
+		}
+
+		for _, address := range imports {
+			j, ok := indices[address]
+			if ok && j != i {
+				dependencies[i] = append(dependencies[i], j)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(contracts))
+	ordered := make([]Contract, 0, len(contracts))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("contract %s has a cyclic dependency", contracts[i].Name)
+		}
+
+		state[i] = visiting
+
+		for _, j := range dependencies[i] {
+			err := visit(j)
+			if err != nil {
+				return err
+			}
+		}
+
+		state[i] = visited
+		ordered = append(ordered, contracts[i])
+
+		return nil
+	}
+
+	for i := range contracts {
+		err := visit(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,131 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestDeployContracts(t *testing.T) {
+	addresses := test.AddressGenerator()
+
+	addressA := addresses.New()
+	addressB := addresses.New()
+	addressC := addresses.New()
+
+	contractA := templates.Contract{
+		Name:    "A",
+		Account: addressA,
+		Code:    []byte(`pub contract A {}`),
+	}
+
+	contractB := templates.Contract{
+		Name:    "B",
+		Account: addressB,
+		Code: []byte(fmt.Sprintf(`
+            import A from 0x%s
+
+            pub contract B {}
+        `, addressA.Short())),
+	}
+
+	contractC := templates.Contract{
+		Name:    "C",
+		Account: addressC,
+		Code: []byte(fmt.Sprintf(`
+            import B from 0x%s
+
+            pub contract C {}
+        `, addressB.Short())),
+	}
+
+	t.Run("Dependency chain", func(t *testing.T) {
+		txs, err := templates.DeployContracts([]templates.Contract{contractC, contractA, contractB})
+		require.NoError(t, err)
+		require.Len(t, txs, 3)
+
+		expected := []templates.Contract{contractA, contractB, contractC}
+
+		for i, contract := range expected {
+			assert.Equal(t, templates.UpdateAccountCode(contract.Code), txs[i].Script)
+			assert.Equal(t, []flow.Address{contract.Account}, txs[i].Authorizers)
+		}
+	})
+
+	t.Run("Independent contracts keep their order", func(t *testing.T) {
+		txs, err := templates.DeployContracts([]templates.Contract{contractB, contractA})
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+
+		assert.Equal(t, templates.UpdateAccountCode(contractA.Code), txs[0].Script)
+		assert.Equal(t, templates.UpdateAccountCode(contractB.Code), txs[1].Script)
+
+		txs, err = templates.DeployContracts([]templates.Contract{contractC, contractA})
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+
+		assert.Equal(t, templates.UpdateAccountCode(contractC.Code), txs[0].Script)
+		assert.Equal(t, templates.UpdateAccountCode(contractA.Code), txs[1].Script)
+	})
+
+	t.Run("Cyclic dependency", func(t *testing.T) {
+		cyclicA := templates.Contract{
+			Name:    "A",
+			Account: addressA,
+			Code: []byte(fmt.Sprintf(`
+                import C from 0x%s
+
+                pub contract A {}
+            `, addressC.Short())),
+		}
+
+		_, err := templates.DeployContracts([]templates.Contract{cyclicA, contractB, contractC})
+		assert.Error(t, err)
+	})
+
+	t.Run("Duplicate account", func(t *testing.T) {
+		duplicate := templates.Contract{
+			Name:    "D",
+			Account: addressA,
+			Code:    []byte(`pub contract D {}`),
+		}
+
+		_, err := templates.DeployContracts([]templates.Contract{contractA, duplicate})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		invalid := templates.Contract{
+			Name:    "D",
+			Account: addressA,
+			Code:    []byte(`pub contract D {`),
+		}
+
+		_, err := templates.DeployContracts([]templates.Contract{invalid})
+		assert.Error(t, err)
+	})
+}
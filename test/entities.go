@@ -21,6 +21,7 @@ package test
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 
@@ -269,8 +270,10 @@ func (g *TransactionResults) New() flow.TransactionResult {
 }
 
 type Events struct {
-	count int
-	ids   *Identifiers
+	count     int
+	ids       *Identifiers
+	eventType string
+	fields    []cadence.Field
 }
 
 func EventGenerator() *Events {
@@ -280,7 +283,19 @@ func EventGenerator() *Events {
 	}
 }
 
+// WithType configures the generator to produce events of the given type, with a payload
+// containing a generated value for each of the given fields.
+func (g *Events) WithType(eventType string, fields []cadence.Field) *Events {
+	g.eventType = eventType
+	g.fields = fields
+	return g
+}
+
 func (g *Events) New() flow.Event {
+	if g.eventType != "" {
+		return g.newWithType()
+	}
+
 	identifier := fmt.Sprintf("FooEvent%d", g.count)
 	typeID := "test." + identifier
 
@@ -317,3 +332,74 @@ func (g *Events) New() flow.Event {
 
 	return event
 }
+
+func (g *Events) newWithType() flow.Event {
+	identifier := g.eventType
+	if i := strings.LastIndex(identifier, "."); i >= 0 {
+		identifier = identifier[i+1:]
+	}
+
+	eventType := cadence.EventType{
+		TypeID:     g.eventType,
+		Identifier: identifier,
+		Fields:     g.fields,
+	}
+
+	values := make([]cadence.Value, len(g.fields))
+	for i, field := range g.fields {
+		values[i] = g.newValue(field.Type)
+	}
+
+	event := flow.Event{
+		Type:             g.eventType,
+		TransactionID:    g.ids.New(),
+		TransactionIndex: g.count,
+		EventIndex:       g.count,
+		Value:            cadence.NewEvent(values).WithType(eventType),
+	}
+
+	g.count++
+
+	return event
+}
+
+func (g *Events) newValue(t cadence.Type) cadence.Value {
+	switch t := t.(type) {
+	case cadence.BoolType:
+		return cadence.NewBool(g.count%2 == 0)
+	case cadence.StringType:
+		return cadence.NewString(fmt.Sprintf("foo%d", g.count))
+	case cadence.AddressType:
+		return cadence.NewAddressFromBytes([]byte{uint8(g.count)})
+	case cadence.IntType:
+		return cadence.NewInt(g.count)
+	case cadence.Int8Type:
+		return cadence.NewInt8(int8(g.count))
+	case cadence.Int16Type:
+		return cadence.NewInt16(int16(g.count))
+	case cadence.Int32Type:
+		return cadence.NewInt32(int32(g.count))
+	case cadence.Int64Type:
+		return cadence.NewInt64(int64(g.count))
+	case cadence.UIntType:
+		return cadence.NewUInt(uint(g.count))
+	case cadence.UInt8Type:
+		return cadence.NewUInt8(uint8(g.count))
+	case cadence.UInt16Type:
+		return cadence.NewUInt16(uint16(g.count))
+	case cadence.UInt32Type:
+		return cadence.NewUInt32(uint32(g.count))
+	case cadence.UInt64Type:
+		return cadence.NewUInt64(uint64(g.count))
+	case cadence.Fix64Type:
+		return cadence.NewFix64(int64(g.count) * 100000000)
+	case cadence.UFix64Type:
+		return cadence.NewUFix64(uint64(g.count) * 100000000)
+	case cadence.OptionalType:
+		return cadence.NewOptional(g.newValue(t.Type))
+	case cadence.VariableSizedArrayType:
+		return cadence.NewArray([]cadence.Value{g.newValue(t.ElementType)})
+	default:
+		panic(fmt.Sprintf("cannot generate value of type %T", t))
+	}
+}
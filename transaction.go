@@ -19,11 +19,26 @@
 package flow
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 )
 
+// DefaultMaxGasLimit is the maximum gas limit accepted for a single transaction
+// by the access and collection nodes of the Flow networks.
+//
+// The Access API does not expose the limit configured on a node, so this default
+// cannot be checked against the network at runtime.
+const DefaultMaxGasLimit uint64 = 9999
+
+// DefaultTransactionExpiry is the number of blocks after its reference block for which
+// a transaction remains valid.
+const DefaultTransactionExpiry uint64 = 600
+
 // A Transaction is a full transaction object containing a payload and signatures.
 type Transaction struct {
 	Script             []byte
@@ -34,6 +49,9 @@ type Transaction struct {
 	Authorizers        []Address
 	PayloadSignatures  []TransactionSignature
 	EnvelopeSignatures []TransactionSignature
+
+	// metadata is local-only data that is never encoded or sent to the network
+	metadata map[string]string
 }
 
 // NewTransaction initializes and returns an empty transaction.
@@ -43,7 +61,7 @@ func NewTransaction() *Transaction {
 
 // ID returns the canonical SHA3-256 hash of this transaction.
 func (t *Transaction) ID() Identifier {
-	return HashToID(DefaultHasher.ComputeHash(t.Encode()))
+	return HashToID(canonicalHash(t.Encode()))
 }
 
 // SetScript sets the Cadence script for this transaction.
@@ -90,6 +108,100 @@ func (t *Transaction) AddAuthorizer(address Address) *Transaction {
 	return t
 }
 
+// SetMetadata sets a local metadata value for this transaction.
+//
+// Metadata is only kept in memory to help track the transaction locally. It is not part
+// of the transaction encoding, so it does not affect the transaction ID and is not sent
+// to the network.
+func (t *Transaction) SetMetadata(key, value string) *Transaction {
+	if t.metadata == nil {
+		t.metadata = make(map[string]string)
+	}
+
+	t.metadata[key] = value
+	return t
+}
+
+// Metadata returns a copy of the local metadata set on this transaction.
+func (t *Transaction) Metadata() map[string]string {
+	metadata := make(map[string]string, len(t.metadata))
+	for key, value := range t.metadata {
+		metadata[key] = value
+	}
+
+	return metadata
+}
+
+// TransactionValidationErrors is the list of problems found when validating a transaction.
+type TransactionValidationErrors []error
+
+func (e TransactionValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("invalid transaction:\n%s", strings.Join(messages, "\n"))
+}
+
+// Validate returns an error if this transaction is missing required fields
+// or is otherwise not well-formed.
+//
+// A transaction is well-formed if it has a non-empty script, a reference block ID,
+// a proposal key with an address and a non-negative key ID, a payer and no empty
+// authorizer addresses. Every signature must be from the proposer, payer or an authorizer.
+//
+// The returned error is of type TransactionValidationErrors and lists all problems found.
+// Signatures are not verified.
+func (t *Transaction) Validate() error {
+	var errs TransactionValidationErrors
+
+	if len(t.Script) == 0 {
+		errs = append(errs, errors.New("script is empty"))
+	}
+
+	if t.ReferenceBlockID == ZeroID {
+		errs = append(errs, errors.New("reference block ID is not set"))
+	}
+
+	if t.ProposalKey.Address == ZeroAddress {
+		errs = append(errs, errors.New("proposal key address is not set"))
+	}
+
+	if t.ProposalKey.KeyID < 0 {
+		errs = append(errs, fmt.Errorf("proposal key ID %d is negative", t.ProposalKey.KeyID))
+	}
+
+	if t.Payer == ZeroAddress {
+		errs = append(errs, errors.New("payer is not set"))
+	}
+
+	for i, authorizer := range t.Authorizers {
+		if authorizer == ZeroAddress {
+			errs = append(errs, fmt.Errorf("authorizer %d is not set", i))
+		}
+	}
+
+	signers := t.signerMap()
+
+	checkSignatures := func(kind string, signatures []TransactionSignature) {
+		for _, sig := range signatures {
+			if _, ok := signers[sig.Address]; !ok {
+				errs = append(errs, fmt.Errorf("%s signature from %s, which is not a signer", kind, sig.Address))
+			}
+		}
+	}
+
+	checkSignatures("payload", t.PayloadSignatures)
+	checkSignatures("envelope", t.EnvelopeSignatures)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 // signerList returns a list of unique accounts required to sign this transaction.
 //
 // The list is returned in the following order:
@@ -139,14 +251,63 @@ func (t *Transaction) signerMap() map[Address]int {
 	return signers
 }
 
+// ReferencedAddresses returns the addresses of all accounts referenced by this transaction,
+// without duplicates: the proposer, payer and authorizers, in signer order, followed by the
+// accounts imported by the script.
+//
+// Transactions in this version of the format have no arguments, so addresses passed as
+// values in the script body are not included.
+//
+// This function returns an error if the script cannot be parsed.
+func (t *Transaction) ReferencedAddresses() ([]Address, error) {
+	addresses := t.signerList()
+
+	imports, err := ParseContractImports(t.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[Address]struct{})
+	for _, address := range addresses {
+		seen[address] = struct{}{}
+	}
+
+	for _, address := range imports {
+		if _, ok := seen[address]; ok {
+			continue
+		}
+
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
 // SignPayload signs the transaction payload with the specified account key.
 //
 // The resulting signature is combined with the account address and key ID before
 // being added to the transaction.
 //
-// This function returns an error if the signature cannot be generated.
+// This function returns an error if the account is not the proposer or an authorizer
+// of the transaction, or if the signature cannot be generated.
 func (t *Transaction) SignPayload(address Address, keyID int, signer crypto.Signer) error {
-	sig, err := signer.Sign(t.PayloadMessage())
+	return t.SignPayloadContext(context.Background(), address, keyID, signer)
+}
+
+// SignPayloadContext signs the transaction payload with the specified account key.
+//
+// If the signer implements crypto.SignerContext, ctx is passed to it, so that a signing request
+// to a remote service can be canceled.
+//
+// This function returns an error if the account is not the proposer or an authorizer
+// of the transaction, or if the signature cannot be generated.
+func (t *Transaction) SignPayloadContext(ctx context.Context, address Address, keyID int, signer crypto.Signer) error {
+	if !t.hasRole(address, SignerRoleProposer) && !t.hasRole(address, SignerRoleAuthorizer) {
+		return fmt.Errorf("account %s is not the proposer or an authorizer of the transaction", address)
+	}
+
+	sig, err := crypto.NewSignerContext(signer).SignContext(ctx, t.PayloadMessage())
 	if err != nil {
 		// TODO: wrap error
 		return err
@@ -162,9 +323,25 @@ func (t *Transaction) SignPayload(address Address, keyID int, signer crypto.Sign
 // The resulting signature is combined with the account address and key ID before
 // being added to the transaction.
 //
-// This function returns an error if the signature cannot be generated.
+// This function returns an error if the account is not the payer or an authorizer
+// of the transaction, or if the signature cannot be generated.
 func (t *Transaction) SignEnvelope(address Address, keyID int, signer crypto.Signer) error {
-	sig, err := signer.Sign(t.EnvelopeMessage())
+	return t.SignEnvelopeContext(context.Background(), address, keyID, signer)
+}
+
+// SignEnvelopeContext signs the full transaction (payload + payload signatures) with the specified account key.
+//
+// If the signer implements crypto.SignerContext, ctx is passed to it, so that a signing request
+// to a remote service can be canceled.
+//
+// This function returns an error if the account is not the payer or an authorizer
+// of the transaction, or if the signature cannot be generated.
+func (t *Transaction) SignEnvelopeContext(ctx context.Context, address Address, keyID int, signer crypto.Signer) error {
+	if !t.hasRole(address, SignerRolePayer) && !t.hasRole(address, SignerRoleAuthorizer) {
+		return fmt.Errorf("account %s is not the payer or an authorizer of the transaction", address)
+	}
+
+	sig, err := crypto.NewSignerContext(signer).SignContext(ctx, t.EnvelopeMessage())
 	if err != nil {
 		// TODO: wrap error
 		return err
@@ -175,12 +352,104 @@ func (t *Transaction) SignEnvelope(address Address, keyID int, signer crypto.Sig
 	return nil
 }
 
+// A SigningRequest describes a signature that is still required for a transaction.
+type SigningRequest struct {
+	// Address is the address of the account that must sign.
+	Address Address
+	// KeyID is the ID of the account key that signs.
+	//
+	// For the proposer this is the proposal key. For other signers the transaction does not record
+	// which key signs, so the ID is 0; set it to the ID of the signing key before attaching the signature.
+	KeyID int
+	// Message is the exact message to sign.
+	Message []byte
+	// Envelope is true if the request is for the envelope signature of the payer,
+	// and false if it is for a payload signature.
+	Envelope bool
+}
+
+// SigningRequests returns the signatures that are still required for this transaction,
+// one request per signing account, in signer order.
+//
+// Payload signatures are required from the proposer and the authorizers, unless they are also the payer.
+// The envelope signature of the payer covers the payload signatures, so the envelope request is only
+// returned once no payload signatures are missing. Attach the payload signatures with AddSignature and
+// call SigningRequests again to obtain the envelope request.
+//
+// This function returns an error if the proposal key or payer is not set.
+func (t *Transaction) SigningRequests() ([]SigningRequest, error) {
+	if t.ProposalKey.Address == ZeroAddress {
+		return nil, errors.New("proposal key address is not set")
+	}
+
+	if t.Payer == ZeroAddress {
+		return nil, errors.New("payer is not set")
+	}
+
+	keyID := func(address Address) int {
+		if address == t.ProposalKey.Address {
+			return t.ProposalKey.KeyID
+		}
+
+		return 0
+	}
+
+	hasSigned := func(signatures []TransactionSignature, address Address) bool {
+		for _, sig := range signatures {
+			if sig.Address != address {
+				continue
+			}
+
+			if address != t.ProposalKey.Address || sig.KeyID == t.ProposalKey.KeyID {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var requests []SigningRequest
+
+	for _, address := range t.signerList() {
+		if address == t.Payer || hasSigned(t.PayloadSignatures, address) {
+			continue
+		}
+
+		requests = append(requests, SigningRequest{
+			Address: address,
+			KeyID:   keyID(address),
+			Message: t.PayloadMessage(),
+		})
+	}
+
+	if len(requests) == 0 && !hasSigned(t.EnvelopeSignatures, t.Payer) {
+		requests = append(requests, SigningRequest{
+			Address:  t.Payer,
+			KeyID:    keyID(t.Payer),
+			Message:  t.EnvelopeMessage(),
+			Envelope: true,
+		})
+	}
+
+	return requests, nil
+}
+
+// AddSignature adds the signature for a signing request to the transaction, as a payload
+// or envelope signature depending on the request.
+func (t *Transaction) AddSignature(request SigningRequest, sig []byte) *Transaction {
+	if request.Envelope {
+		return t.AddEnvelopeSignature(request.Address, request.KeyID, sig)
+	}
+
+	return t.AddPayloadSignature(request.Address, request.KeyID, sig)
+}
+
 // AddPayloadSignature adds a payload signature to the transaction for the given address and key ID.
 func (t *Transaction) AddPayloadSignature(address Address, keyID int, sig []byte) *Transaction {
 	s := t.createSignature(address, keyID, sig)
 
 	t.PayloadSignatures = append(t.PayloadSignatures, s)
-	sort.Slice(t.PayloadSignatures, compareSignatures(t.PayloadSignatures))
+	sort.SliceStable(t.PayloadSignatures, compareSignatures(t.PayloadSignatures))
 
 	return t
 }
@@ -190,7 +459,7 @@ func (t *Transaction) AddEnvelopeSignature(address Address, keyID int, sig []byt
 	s := t.createSignature(address, keyID, sig)
 
 	t.EnvelopeSignatures = append(t.EnvelopeSignatures, s)
-	sort.Slice(t.EnvelopeSignatures, compareSignatures(t.EnvelopeSignatures))
+	sort.SliceStable(t.EnvelopeSignatures, compareSignatures(t.EnvelopeSignatures))
 
 	return t
 }
@@ -209,6 +478,131 @@ func (t *Transaction) createSignature(address Address, keyID int, sig []byte) Tr
 	}
 }
 
+// AuthorizationWeights returns the total weight of the keys that have signed this transaction,
+// grouped by account address.
+//
+// The accounts argument maps each signing account to its keys. Every signature is verified
+// against its key; payload signatures against the payload message and envelope signatures
+// against the envelope message. A key that signs more than once only contributes its weight once.
+// Account keys cannot currently be revoked, so every key contributes its full weight.
+//
+// This function returns an error if a signature references an account or key that is
+// not present in accounts, or if a signature is not valid for its key.
+func (t *Transaction) AuthorizationWeights(accounts map[Address][]*AccountKey) (map[Address]int, error) {
+	payloadKeys, envelopeKeys, err := t.verifySigningKeys(accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, weight := range envelopeKeys {
+		payloadKeys[key] = weight
+	}
+
+	return sumSigningKeyWeights(payloadKeys), nil
+}
+
+// SignatureWeights returns the total weight of the keys that have signed the payload and the
+// envelope of this transaction, each grouped by account address.
+//
+// Signatures are verified as in AuthorizationWeights. A key that signs both the payload and the
+// envelope contributes its weight to both. The payer must reach the weight threshold with
+// envelope signatures alone, and an authorizer that is not the payer with payload signatures alone.
+func (t *Transaction) SignatureWeights(
+	accounts map[Address][]*AccountKey,
+) (payload map[Address]int, envelope map[Address]int, err error) {
+	payloadKeys, envelopeKeys, err := t.verifySigningKeys(accounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sumSigningKeyWeights(payloadKeys), sumSigningKeyWeights(envelopeKeys), nil
+}
+
+type signingKey struct {
+	address Address
+	keyID   int
+}
+
+// verifySigningKeys verifies the payload and envelope signatures of this transaction,
+// returning the weight of each key that has signed the payload and the envelope.
+func (t *Transaction) verifySigningKeys(
+	accounts map[Address][]*AccountKey,
+) (payload map[signingKey]int, envelope map[signingKey]int, err error) {
+	payload, err = verifySignatures(accounts, t.PayloadSignatures, t.PayloadMessage())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envelope, err = verifySignatures(accounts, t.EnvelopeSignatures, t.EnvelopeMessage())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return payload, envelope, nil
+}
+
+func verifySignatures(
+	accounts map[Address][]*AccountKey,
+	signatures []TransactionSignature,
+	message []byte,
+) (map[signingKey]int, error) {
+	keys := make(map[signingKey]int)
+
+	for _, sig := range signatures {
+		accountKey, err := findAccountKey(accounts, sig.Address, sig.KeyID)
+		if err != nil {
+			return nil, err
+		}
+
+		hasher, err := accountKey.Hasher()
+		if err != nil {
+			return nil, fmt.Errorf("key %d on account %s: %w", sig.KeyID, sig.Address, err)
+		}
+
+		valid, err := accountKey.PublicKey.Verify(sig.Signature, message, hasher)
+		if err != nil {
+			return nil, fmt.Errorf("key %d on account %s: %w", sig.KeyID, sig.Address, err)
+		}
+
+		if !valid {
+			return nil, fmt.Errorf("invalid signature for key %d on account %s", sig.KeyID, sig.Address)
+		}
+
+		keys[signingKey{sig.Address, sig.KeyID}] = accountKey.Weight
+	}
+
+	return keys, nil
+}
+
+func sumSigningKeyWeights(keys map[signingKey]int) map[Address]int {
+	weights := make(map[Address]int)
+
+	for key, weight := range keys {
+		weights[key.address] += weight
+	}
+
+	return weights
+}
+
+func findAccountKey(accounts map[Address][]*AccountKey, address Address, keyID int) (*AccountKey, error) {
+	keys, ok := accounts[address]
+	if !ok {
+		return nil, fmt.Errorf("account %s is missing", address)
+	}
+
+	for _, key := range keys {
+		if key.ID == keyID {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key %d on account %s is missing", keyID, address)
+}
+
+// PayloadMessage returns the signable message for the transaction payload.
+//
+// The message is the RLP encoding of the payload fields. It is signed by the proposer
+// and authorizers, unless they are also the payer.
 func (t *Transaction) PayloadMessage() []byte {
 	temp := t.payloadCanonicalForm()
 	return mustRLPEncode(&temp)
@@ -249,6 +643,24 @@ func (t *Transaction) EnvelopeMessage() []byte {
 	return mustRLPEncode(&temp)
 }
 
+// EnvelopeDigest returns the hash of the transaction envelope message computed with the
+// given hash algorithm.
+//
+// The hashed bytes are exactly those returned by EnvelopeMessage: the RLP encoding of the
+// transaction payload and payload signatures. No domain tag is prepended to the message.
+//
+// The digest can be signed by a signer that does not hash its input, such as a hardware
+// security module, and the resulting signature added with AddEnvelopeSignature.
+// It is only valid until the payload or payload signatures change.
+func (t *Transaction) EnvelopeDigest(hashAlgo crypto.HashAlgorithm) ([]byte, error) {
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.ComputeHash(t.EnvelopeMessage()), nil
+}
+
 func (t *Transaction) envelopeCanonicalForm() interface{} {
 	return struct {
 		Payload           interface{}
@@ -273,6 +685,189 @@ func (t *Transaction) Encode() []byte {
 	return mustRLPEncode(&temp)
 }
 
+// DecodeTransaction decodes a transaction from its canonical RLP byte representation,
+// as produced by Transaction.Encode.
+//
+// An error is returned if the data is malformed or if a signature references a signer
+// that is not the proposer, payer or an authorizer of the transaction.
+func DecodeTransaction(b []byte) (*Transaction, error) {
+	var temp transactionWrapper
+
+	err := rlpDecode(b, &temp)
+	if err != nil {
+		return nil, err
+	}
+
+	t := NewTransaction().
+		SetScript(temp.Payload.Script).
+		SetReferenceBlockID(BytesToID(temp.Payload.ReferenceBlockID)).
+		SetGasLimit(temp.Payload.GasLimit).
+		SetProposalKey(
+			BytesToAddress(temp.Payload.ProposalKeyAddress),
+			int(temp.Payload.ProposalKeyID),
+			temp.Payload.ProposalKeySequenceNumber,
+		).
+		SetPayer(BytesToAddress(temp.Payload.Payer))
+
+	for _, authorizer := range temp.Payload.Authorizers {
+		t.AddAuthorizer(BytesToAddress(authorizer))
+	}
+
+	signers := t.signerList()
+
+	decodeSignatures := func(wrappers []transactionSignatureWrapper) ([]TransactionSignature, error) {
+		if len(wrappers) == 0 {
+			return nil, nil
+		}
+
+		signatures := make([]TransactionSignature, len(wrappers))
+
+		for i, w := range wrappers {
+			if w.SignerIndex >= uint64(len(signers)) {
+				return nil, fmt.Errorf("signature references unknown signer %d", w.SignerIndex)
+			}
+
+			signatures[i] = TransactionSignature{
+				Address:     signers[w.SignerIndex],
+				SignerIndex: int(w.SignerIndex),
+				KeyID:       int(w.KeyID),
+				Signature:   w.Signature,
+			}
+		}
+
+		return signatures, nil
+	}
+
+	t.PayloadSignatures, err = decodeSignatures(temp.PayloadSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	t.EnvelopeSignatures, err = decodeSignatures(temp.EnvelopeSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// AddPayloadSignatureFrom decodes a partially-signed transaction from its canonical
+// RLP byte representation, replaces this transaction with it and adds a payload signature
+// for the specified account key.
+//
+// Existing signatures are preserved. Because the envelope message includes the payload
+// signatures, an error is returned if the decoded transaction already contains envelope signatures.
+func (t *Transaction) AddPayloadSignatureFrom(data []byte, address Address, keyID int, signer crypto.Signer) error {
+	decoded, err := DecodeTransaction(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	if len(decoded.EnvelopeSignatures) > 0 {
+		return fmt.Errorf("cannot add payload signature to a transaction with envelope signatures")
+	}
+
+	err = decoded.SignPayload(address, keyID, signer)
+	if err != nil {
+		return err
+	}
+
+	*t = *decoded
+
+	return nil
+}
+
+// SortTransactionsBySequence sorts transactions that share a proposal key by ascending
+// sequence number, in place.
+//
+// The transactions of each proposal key are reordered among the positions they already
+// occupy, so the relative order of transactions with different proposal keys is preserved.
+//
+// An error is returned, and the transactions are left unchanged, if two transactions with
+// the same proposal key have the same sequence number, or if there is a gap between the
+// sequence numbers of a proposal key.
+func SortTransactionsBySequence(txs []*Transaction) error {
+	type proposalKey struct {
+		address Address
+		keyID   int
+	}
+
+	positions := make(map[proposalKey][]int)
+	keys := make([]proposalKey, 0)
+
+	for i, tx := range txs {
+		key := proposalKey{address: tx.ProposalKey.Address, keyID: tx.ProposalKey.KeyID}
+
+		if _, ok := positions[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		positions[key] = append(positions[key], i)
+	}
+
+	sorted := make([]*Transaction, len(txs))
+
+	for _, key := range keys {
+		group := make([]*Transaction, len(positions[key]))
+		for i, position := range positions[key] {
+			group[i] = txs[position]
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].ProposalKey.SequenceNumber < group[j].ProposalKey.SequenceNumber
+		})
+
+		for i := 1; i < len(group); i++ {
+			prev := group[i-1].ProposalKey.SequenceNumber
+			next := group[i].ProposalKey.SequenceNumber
+
+			switch {
+			case next == prev:
+				return fmt.Errorf(
+					"duplicate sequence number %d for proposal key %d on account %s",
+					next, key.keyID, key.address,
+				)
+			case next != prev+1:
+				return fmt.Errorf(
+					"gap between sequence numbers %d and %d for proposal key %d on account %s",
+					prev, next, key.keyID, key.address,
+				)
+			}
+		}
+
+		for i, position := range positions[key] {
+			sorted[position] = group[i]
+		}
+	}
+
+	copy(txs, sorted)
+
+	return nil
+}
+
+type transactionWrapper struct {
+	Payload            transactionPayloadWrapper
+	PayloadSignatures  []transactionSignatureWrapper
+	EnvelopeSignatures []transactionSignatureWrapper
+}
+
+type transactionPayloadWrapper struct {
+	Script                    []byte
+	ReferenceBlockID          []byte
+	GasLimit                  uint64
+	ProposalKeyAddress        []byte
+	ProposalKeyID             uint64
+	ProposalKeySequenceNumber uint64
+	Payer                     []byte
+	Authorizers               [][]byte
+}
+
+type transactionSignatureWrapper struct {
+	SignerIndex uint64
+	KeyID       uint64
+	Signature   []byte
+}
+
 // A ProposalKey is the key that specifies the proposal key and sequence number for a transaction.
 type ProposalKey struct {
 	Address        Address
@@ -304,7 +899,12 @@ func compareSignatures(signatures []TransactionSignature) func(i, j int) bool {
 	return func(i, j int) bool {
 		sigA := signatures[i]
 		sigB := signatures[j]
-		return sigA.SignerIndex < sigB.SignerIndex || sigA.KeyID < sigB.KeyID
+
+		if sigA.SignerIndex != sigB.SignerIndex {
+			return sigA.SignerIndex < sigB.SignerIndex
+		}
+
+		return sigA.KeyID < sigB.KeyID
 	}
 }
 
@@ -326,6 +926,19 @@ type TransactionResult struct {
 	Events []Event
 }
 
+// EventsOfType returns the events in this result with the given type, in the order they were emitted.
+func (r TransactionResult) EventsOfType(eventType string) []Event {
+	events := make([]Event, 0)
+
+	for _, event := range r.Events {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
 // TransactionStatus represents the status of a transaction.
 type TransactionStatus int
 
@@ -0,0 +1,86 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+// A TransactionBuilder builds a transaction with chained calls and validates it when built.
+//
+// Transaction arguments are not supported by this version of the transaction format, so
+// values must be included in the script.
+type TransactionBuilder struct {
+	tx Transaction
+}
+
+// NewTransactionBuilder returns a builder for a new, empty transaction.
+func NewTransactionBuilder() *TransactionBuilder {
+	return &TransactionBuilder{}
+}
+
+// Script sets the Cadence script of the transaction.
+func (b *TransactionBuilder) Script(script []byte) *TransactionBuilder {
+	b.tx.SetScript(script)
+	return b
+}
+
+// ReferenceBlockID sets the reference block ID of the transaction.
+func (b *TransactionBuilder) ReferenceBlockID(blockID Identifier) *TransactionBuilder {
+	b.tx.SetReferenceBlockID(blockID)
+	return b
+}
+
+// GasLimit sets the gas limit of the transaction.
+func (b *TransactionBuilder) GasLimit(limit uint64) *TransactionBuilder {
+	b.tx.SetGasLimit(limit)
+	return b
+}
+
+// ProposalKey sets the proposal key and sequence number of the transaction.
+func (b *TransactionBuilder) ProposalKey(address Address, keyID int, sequenceNum uint64) *TransactionBuilder {
+	b.tx.SetProposalKey(address, keyID, sequenceNum)
+	return b
+}
+
+// Payer sets the payer account of the transaction.
+func (b *TransactionBuilder) Payer(address Address) *TransactionBuilder {
+	b.tx.SetPayer(address)
+	return b
+}
+
+// Authorizer adds an authorizer account to the transaction.
+func (b *TransactionBuilder) Authorizer(address Address) *TransactionBuilder {
+	b.tx.AddAuthorizer(address)
+	return b
+}
+
+// Build returns the transaction, or an error of type TransactionValidationErrors listing
+// every required field that is missing or invalid.
+//
+// The builder can be used again after Build; later calls do not modify the returned transaction.
+func (b *TransactionBuilder) Build() (*Transaction, error) {
+	tx := b.tx
+
+	tx.Script = append([]byte(nil), b.tx.Script...)
+	tx.Authorizers = append([]Address(nil), b.tx.Authorizers...)
+
+	err := tx.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
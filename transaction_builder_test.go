@@ -0,0 +1,106 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestTransactionBuilder(t *testing.T) {
+	addresses := test.AddressGenerator()
+	ids := test.IdentifierGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizer := addresses.New()
+	blockID := ids.New()
+
+	script := []byte(`transaction { execute { log("Hello, World!") } }`)
+
+	newBuilder := func() *flow.TransactionBuilder {
+		return flow.NewTransactionBuilder().
+			Script(script).
+			ReferenceBlockID(blockID).
+			GasLimit(42).
+			ProposalKey(proposer, 1, 7).
+			Payer(payer).
+			Authorizer(authorizer)
+	}
+
+	t.Run("Complete", func(t *testing.T) {
+		tx, err := newBuilder().Build()
+		require.NoError(t, err)
+
+		expected := flow.NewTransaction().
+			SetScript(script).
+			SetReferenceBlockID(blockID).
+			SetGasLimit(42).
+			SetProposalKey(proposer, 1, 7).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+
+		assert.Equal(t, expected, tx)
+	})
+
+	t.Run("Missing payer", func(t *testing.T) {
+		_, err := newBuilder().Payer(flow.ZeroAddress).Build()
+		require.Error(t, err)
+
+		var validationErrs flow.TransactionValidationErrors
+		require.True(t, errors.As(err, &validationErrs))
+		require.Len(t, validationErrs, 1)
+
+		assert.Contains(t, err.Error(), "payer is not set")
+	})
+
+	t.Run("Missing reference block ID", func(t *testing.T) {
+		_, err := newBuilder().ReferenceBlockID(flow.ZeroID).Build()
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "reference block ID is not set")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := flow.NewTransactionBuilder().Build()
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "script is empty")
+		assert.Contains(t, err.Error(), "reference block ID is not set")
+		assert.Contains(t, err.Error(), "proposal key address is not set")
+		assert.Contains(t, err.Error(), "payer is not set")
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		builder := newBuilder()
+
+		tx, err := builder.Build()
+		require.NoError(t, err)
+
+		builder.Authorizer(addresses.New())
+
+		assert.Equal(t, []flow.Address{authorizer}, tx.Authorizers)
+	})
+}
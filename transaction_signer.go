@@ -0,0 +1,169 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// A SignerRole is the role in which an account key signs a transaction.
+type SignerRole int
+
+const (
+	// SignerRoleProposer signs the payload with the proposal key.
+	SignerRoleProposer SignerRole = iota
+	// SignerRoleAuthorizer signs the payload on behalf of an authorizer.
+	SignerRoleAuthorizer
+	// SignerRolePayer signs the envelope on behalf of the payer.
+	SignerRolePayer
+)
+
+// String returns the string representation of this signer role.
+func (r SignerRole) String() string {
+	switch r {
+	case SignerRoleProposer:
+		return "proposer"
+	case SignerRoleAuthorizer:
+		return "authorizer"
+	case SignerRolePayer:
+		return "payer"
+	default:
+		return "unknown"
+	}
+}
+
+// A TransactionSigner describes an account key that signs a transaction in a given role.
+type TransactionSigner struct {
+	Address Address
+	KeyID   int
+	Signer  crypto.Signer
+	Role    SignerRole
+}
+
+// SignAll produces and attaches all the signatures of the given signers to the transaction.
+//
+// Proposer and authorizer signers sign the payload, unless their account is also the payer:
+// the envelope signature of the payer covers all of its roles, so a single payer signer is
+// enough for a transaction that is proposed, authorized and paid for by one account.
+// Payer signers sign the envelope once all payload signatures are present.
+//
+// An error is returned if a signer does not hold its role in the transaction, if there is
+// no payer signer, or if a payload signature is still missing after the payload is signed.
+// In that case no envelope signature is added.
+func (t *Transaction) SignAll(signers []TransactionSigner) error {
+	return t.SignAllContext(context.Background(), signers)
+}
+
+// SignAllContext produces and attaches all the signatures of the given signers to the transaction.
+//
+// It behaves like SignAll, and passes ctx to signers that implement crypto.SignerContext.
+func (t *Transaction) SignAllContext(ctx context.Context, signers []TransactionSigner) error {
+	type accountKey struct {
+		address Address
+		keyID   int
+	}
+
+	payloadSigners := make([]TransactionSigner, 0, len(signers))
+	envelopeSigners := make([]TransactionSigner, 0, 1)
+
+	// an account key that signs in several roles only signs each message once
+	payloadKeys := make(map[accountKey]bool)
+	envelopeKeys := make(map[accountKey]bool)
+
+	for _, signer := range signers {
+		if !t.hasRole(signer.Address, signer.Role) {
+			return fmt.Errorf("account %s is not the %s of the transaction", signer.Address, signer.Role)
+		}
+
+		key := accountKey{address: signer.Address, keyID: signer.KeyID}
+
+		switch {
+		case signer.Role == SignerRolePayer:
+			if !envelopeKeys[key] {
+				envelopeKeys[key] = true
+				envelopeSigners = append(envelopeSigners, signer)
+			}
+		case signer.Address != t.Payer:
+			if !payloadKeys[key] {
+				payloadKeys[key] = true
+				payloadSigners = append(payloadSigners, signer)
+			}
+		}
+	}
+
+	if len(envelopeSigners) == 0 {
+		return errors.New("no signer for the payer")
+	}
+
+	for _, signer := range payloadSigners {
+		err := t.SignPayloadContext(ctx, signer.Address, signer.KeyID, signer.Signer)
+		if err != nil {
+			return err
+		}
+	}
+
+	requests, err := t.SigningRequests()
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+
+	for _, request := range requests {
+		if !request.Envelope {
+			missing = append(missing, request.Address.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing payload signatures from %s", strings.Join(missing, ", "))
+	}
+
+	for _, signer := range envelopeSigners {
+		err := t.SignEnvelopeContext(ctx, signer.Address, signer.KeyID, signer.Signer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasRole reports whether the account holds the given role in this transaction.
+func (t *Transaction) hasRole(address Address, role SignerRole) bool {
+	switch role {
+	case SignerRoleProposer:
+		return address == t.ProposalKey.Address
+	case SignerRolePayer:
+		return address == t.Payer
+	case SignerRoleAuthorizer:
+		for _, authorizer := range t.Authorizers {
+			if authorizer == address {
+				return true
+			}
+		}
+	}
+
+	return false
+}
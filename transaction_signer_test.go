@@ -0,0 +1,167 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestTransaction_SignAll(t *testing.T) {
+	addresses := test.AddressGenerator()
+	accountKeys := test.AccountKeyGenerator()
+
+	proposer := addresses.New()
+	authorizer := addresses.New()
+	payer := addresses.New()
+
+	proposerKey, proposerSigner := accountKeys.NewWithSigner()
+	authorizerKey, authorizerSigner := accountKeys.NewWithSigner()
+	payerKey, payerSigner := accountKeys.NewWithSigner()
+
+	verify := func(t *testing.T, key *flow.AccountKey, sig flow.TransactionSignature, message []byte) {
+		hasher, err := key.Hasher()
+		require.NoError(t, err)
+
+		valid, err := key.PublicKey.Verify(sig.Signature, message, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	newTransaction := func() *flow.Transaction {
+		tx := test.TransactionGenerator().New()
+		tx.PayloadSignatures = nil
+		tx.EnvelopeSignatures = nil
+
+		return tx.
+			SetProposalKey(proposer, proposerKey.ID, 1).
+			SetPayer(payer)
+	}
+
+	t.Run("Single signer", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+		tx.PayloadSignatures = nil
+		tx.EnvelopeSignatures = nil
+		tx.Authorizers = nil
+
+		tx.SetProposalKey(payer, payerKey.ID, 1).
+			SetPayer(payer).
+			AddAuthorizer(payer)
+
+		err := tx.SignAll([]flow.TransactionSigner{
+			{Address: payer, KeyID: payerKey.ID, Signer: payerSigner, Role: flow.SignerRolePayer},
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, tx.PayloadSignatures)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+
+		verify(t, payerKey, tx.EnvelopeSignatures[0], tx.EnvelopeMessage())
+	})
+
+	t.Run("Three parties", func(t *testing.T) {
+		tx := newTransaction()
+		tx.Authorizers = nil
+		tx.AddAuthorizer(authorizer)
+
+		// the payer is listed first, but signs last
+		err := tx.SignAll([]flow.TransactionSigner{
+			{Address: payer, KeyID: payerKey.ID, Signer: payerSigner, Role: flow.SignerRolePayer},
+			{Address: authorizer, KeyID: authorizerKey.ID, Signer: authorizerSigner, Role: flow.SignerRoleAuthorizer},
+			{Address: proposer, KeyID: proposerKey.ID, Signer: proposerSigner, Role: flow.SignerRoleProposer},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, tx.PayloadSignatures, 2)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+
+		assert.Equal(t, proposer, tx.PayloadSignatures[0].Address)
+		assert.Equal(t, authorizer, tx.PayloadSignatures[1].Address)
+		assert.Equal(t, payer, tx.EnvelopeSignatures[0].Address)
+
+		verify(t, proposerKey, tx.PayloadSignatures[0], tx.PayloadMessage())
+		verify(t, authorizerKey, tx.PayloadSignatures[1], tx.PayloadMessage())
+		verify(t, payerKey, tx.EnvelopeSignatures[0], tx.EnvelopeMessage())
+
+		requests, err := tx.SigningRequests()
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("Proposer is authorizer", func(t *testing.T) {
+		tx := newTransaction()
+		tx.Authorizers = nil
+		tx.AddAuthorizer(proposer)
+
+		err := tx.SignAll([]flow.TransactionSigner{
+			{Address: proposer, KeyID: proposerKey.ID, Signer: proposerSigner, Role: flow.SignerRoleProposer},
+			{Address: proposer, KeyID: proposerKey.ID, Signer: proposerSigner, Role: flow.SignerRoleAuthorizer},
+			{Address: payer, KeyID: payerKey.ID, Signer: payerSigner, Role: flow.SignerRolePayer},
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, tx.PayloadSignatures, 1)
+		assert.Len(t, tx.EnvelopeSignatures, 1)
+	})
+
+	t.Run("Missing payload signer", func(t *testing.T) {
+		tx := newTransaction()
+		tx.Authorizers = nil
+		tx.AddAuthorizer(authorizer)
+
+		err := tx.SignAll([]flow.TransactionSigner{
+			{Address: proposer, KeyID: proposerKey.ID, Signer: proposerSigner, Role: flow.SignerRoleProposer},
+			{Address: payer, KeyID: payerKey.ID, Signer: payerSigner, Role: flow.SignerRolePayer},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), authorizer.String())
+
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+
+	t.Run("Missing payer signer", func(t *testing.T) {
+		tx := newTransaction()
+		tx.Authorizers = nil
+
+		err := tx.SignAll([]flow.TransactionSigner{
+			{Address: proposer, KeyID: proposerKey.ID, Signer: proposerSigner, Role: flow.SignerRoleProposer},
+		})
+		assert.Error(t, err)
+
+		assert.Empty(t, tx.PayloadSignatures)
+	})
+
+	t.Run("Wrong role", func(t *testing.T) {
+		tx := newTransaction()
+		tx.Authorizers = nil
+
+		err := tx.SignAll([]flow.TransactionSigner{
+			{Address: proposer, KeyID: proposerKey.ID, Signer: proposerSigner, Role: flow.SignerRolePayer},
+		})
+		assert.Error(t, err)
+
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+}
@@ -19,13 +19,17 @@
 package flow_test
 
 import (
+	"context"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/test"
 )
 
@@ -188,6 +192,25 @@ func TestTransaction_AddAuthorizer(t *testing.T) {
 	assert.Equal(t, addressB, tx.Authorizers[1])
 }
 
+func TestTransaction_SetMetadata(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+
+	id := tx.ID()
+	encoded := tx.Encode()
+
+	tx.SetMetadata("orderID", "1234").
+		SetMetadata("userID", "alice")
+
+	assert.Equal(t, map[string]string{"orderID": "1234", "userID": "alice"}, tx.Metadata())
+
+	assert.Equal(t, id, tx.ID())
+	assert.Equal(t, encoded, tx.Encode())
+
+	// the returned map is a copy
+	tx.Metadata()["orderID"] = "5678"
+	assert.Equal(t, "1234", tx.Metadata()["orderID"])
+}
+
 func TestTransaction_AddPayloadSignature(t *testing.T) {
 	addresses := test.AddressGenerator()
 
@@ -289,6 +312,35 @@ func TestTransaction_AddPayloadSignature(t *testing.T) {
 		assert.Equal(t, keyIDB, tx.PayloadSignatures[1].KeyID)
 		assert.Equal(t, sigB, tx.PayloadSignatures[1].Signature)
 	})
+
+	t.Run("Multiple signers and keys", func(t *testing.T) {
+		addressA := addresses.New()
+		addressB := addresses.New()
+
+		tx := flow.NewTransaction().
+			AddAuthorizer(addressA).
+			AddAuthorizer(addressB)
+
+		tx.AddPayloadSignature(addressB, 1, []byte{1})
+		tx.AddPayloadSignature(addressA, 8, []byte{2})
+		tx.AddPayloadSignature(addressB, 0, []byte{3})
+		tx.AddPayloadSignature(addressA, 7, []byte{4})
+
+		require.Len(t, tx.PayloadSignatures, 4)
+
+		// signatures should be sorted by signer index, then by key ID
+		assert.Equal(t, addressA, tx.PayloadSignatures[0].Address)
+		assert.Equal(t, 7, tx.PayloadSignatures[0].KeyID)
+
+		assert.Equal(t, addressA, tx.PayloadSignatures[1].Address)
+		assert.Equal(t, 8, tx.PayloadSignatures[1].KeyID)
+
+		assert.Equal(t, addressB, tx.PayloadSignatures[2].Address)
+		assert.Equal(t, 0, tx.PayloadSignatures[2].KeyID)
+
+		assert.Equal(t, addressB, tx.PayloadSignatures[3].Address)
+		assert.Equal(t, 1, tx.PayloadSignatures[3].KeyID)
+	})
 }
 
 func TestTransaction_AddEnvelopeSignature(t *testing.T) {
@@ -354,3 +406,804 @@ func TestTransaction_AddEnvelopeSignature(t *testing.T) {
 		assert.Equal(t, sigB, tx.EnvelopeSignatures[1].Signature)
 	})
 }
+
+func TestTransaction_EnvelopeDigest(t *testing.T) {
+	privateKey, err := crypto.GeneratePrivateKey(
+		crypto.ECDSA_secp256k1,
+		[]byte("elephant ears space cowboy octopus rodeo potato cannon pineapple"),
+	)
+	require.NoError(t, err)
+
+	tx := test.TransactionGenerator().New()
+	tx.EnvelopeSignatures = nil
+
+	digest, err := tx.EnvelopeDigest(crypto.SHA3_256)
+	require.NoError(t, err)
+
+	hasher, err := crypto.NewHasher(crypto.SHA3_256)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte(hasher.ComputeHash(tx.EnvelopeMessage())), digest)
+
+	t.Run("Sign digest externally", func(t *testing.T) {
+		// sign the digest without hashing it again, as a digest-signing HSM would
+		ecdsaKey, err := ethcrypto.ToECDSA(privateKey.Encode())
+		require.NoError(t, err)
+
+		sig, err := ethcrypto.Sign(digest, ecdsaKey)
+		require.NoError(t, err)
+
+		// drop the recovery ID to get an r || s signature
+		sig = sig[:64]
+
+		tx.AddEnvelopeSignature(tx.Payer, 0, sig)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+
+		valid, err := privateKey.PublicKey().Verify(tx.EnvelopeSignatures[0].Signature, tx.EnvelopeMessage(), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Invalid hash algorithm", func(t *testing.T) {
+		_, err := tx.EnvelopeDigest(crypto.UnknownHashAlgorithm)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransactionResult_EventsOfType(t *testing.T) {
+	events := test.EventGenerator()
+
+	eventA := events.New()
+	eventB := events.New()
+	eventC := events.New()
+
+	// give the third event the same type as the first
+	eventC.Type = eventA.Type
+
+	result := flow.TransactionResult{
+		Status: flow.TransactionStatusSealed,
+		Events: []flow.Event{eventA, eventB, eventC},
+	}
+
+	t.Run("Matching type", func(t *testing.T) {
+		matches := result.EventsOfType(eventA.Type)
+		assert.Equal(t, []flow.Event{eventA, eventC}, matches)
+	})
+
+	t.Run("Non-matching type", func(t *testing.T) {
+		matches := result.EventsOfType("test.BarEvent")
+		assert.NotNil(t, matches)
+		assert.Empty(t, matches)
+	})
+}
+
+type contextKey string
+
+type contextSigner struct {
+	crypto.Signer
+	values []interface{}
+}
+
+func (s *contextSigner) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	s.values = append(s.values, ctx.Value(contextKey("request")))
+	return s.Sign(message)
+}
+
+func TestTransaction_ReferencedAddresses(t *testing.T) {
+	proposer := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+	authorizer := flow.HexToAddress("03")
+	imported := flow.HexToAddress("04")
+
+	script := []byte(`
+		import FungibleToken from 0x02
+		import FlowToken from 0x04
+		import Foo from 0x04
+
+		transaction {}
+	`)
+
+	t.Run("Roles and imports", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript(script).
+			SetProposalKey(proposer, 0, 0).
+			SetPayer(payer).
+			AddAuthorizer(authorizer).
+			AddAuthorizer(proposer)
+
+		addresses, err := tx.ReferencedAddresses()
+		require.NoError(t, err)
+
+		assert.Equal(t, []flow.Address{proposer, payer, authorizer, imported}, addresses)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		addresses, err := flow.NewTransaction().ReferencedAddresses()
+		require.NoError(t, err)
+
+		assert.Empty(t, addresses)
+	})
+
+	t.Run("Invalid script", func(t *testing.T) {
+		tx := flow.NewTransaction().SetScript([]byte("transaction {"))
+
+		_, err := tx.ReferencedAddresses()
+		assert.Error(t, err)
+	})
+}
+
+func TestSortTransactionsBySequence(t *testing.T) {
+	addressA := flow.HexToAddress("01")
+	addressB := flow.HexToAddress("02")
+
+	newTransaction := func(address flow.Address, keyID int, sequenceNum uint64) *flow.Transaction {
+		return flow.NewTransaction().SetProposalKey(address, keyID, sequenceNum)
+	}
+
+	t.Run("Interleaved keys", func(t *testing.T) {
+		a5 := newTransaction(addressA, 0, 5)
+		a6 := newTransaction(addressA, 0, 6)
+		a7 := newTransaction(addressA, 0, 7)
+		b1 := newTransaction(addressB, 0, 1)
+		b2 := newTransaction(addressB, 0, 2)
+		other := newTransaction(addressA, 1, 9)
+
+		txs := []*flow.Transaction{a7, b2, other, a5, b1, a6}
+
+		err := flow.SortTransactionsBySequence(txs)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*flow.Transaction{a5, b1, other, a6, b2, a7}, txs)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		txs := []*flow.Transaction{
+			newTransaction(addressA, 0, 2),
+			newTransaction(addressA, 0, 1),
+			newTransaction(addressA, 0, 1),
+		}
+
+		original := append([]*flow.Transaction(nil), txs...)
+
+		err := flow.SortTransactionsBySequence(txs)
+		assert.Error(t, err)
+
+		assert.Equal(t, original, txs)
+	})
+
+	t.Run("Gap", func(t *testing.T) {
+		txs := []*flow.Transaction{
+			newTransaction(addressB, 0, 1),
+			newTransaction(addressA, 0, 3),
+			newTransaction(addressA, 0, 1),
+		}
+
+		original := append([]*flow.Transaction(nil), txs...)
+
+		err := flow.SortTransactionsBySequence(txs)
+		assert.Error(t, err)
+
+		assert.Equal(t, original, txs)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		err := flow.SortTransactionsBySequence(nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestTransaction_SignPayloadAndEnvelope(t *testing.T) {
+	addresses := test.AddressGenerator()
+	accountKeys := test.AccountKeyGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizerA := addresses.New()
+	authorizerB := addresses.New()
+
+	key, signer := accountKeys.NewWithSigner()
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.ScriptHelloWorld).
+			SetProposalKey(proposer, key.ID, 1).
+			SetPayer(payer).
+			AddAuthorizer(authorizerA).
+			AddAuthorizer(authorizerB)
+	}
+
+	t.Run("Canonical order", func(t *testing.T) {
+		tx := newTransaction()
+
+		for _, address := range []flow.Address{authorizerB, authorizerA, proposer} {
+			err := tx.SignPayload(address, key.ID, signer)
+			require.NoError(t, err)
+		}
+
+		err := tx.SignEnvelope(payer, key.ID, signer)
+		require.NoError(t, err)
+
+		require.Len(t, tx.PayloadSignatures, 3)
+
+		assert.Equal(t, proposer, tx.PayloadSignatures[0].Address)
+		assert.Equal(t, 0, tx.PayloadSignatures[0].SignerIndex)
+		assert.Equal(t, authorizerA, tx.PayloadSignatures[1].Address)
+		assert.Equal(t, 2, tx.PayloadSignatures[1].SignerIndex)
+		assert.Equal(t, authorizerB, tx.PayloadSignatures[2].Address)
+		assert.Equal(t, 3, tx.PayloadSignatures[2].SignerIndex)
+
+		require.Len(t, tx.EnvelopeSignatures, 1)
+
+		assert.Equal(t, payer, tx.EnvelopeSignatures[0].Address)
+		assert.Equal(t, 1, tx.EnvelopeSignatures[0].SignerIndex)
+	})
+
+	t.Run("Payload signed by payer", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(payer, key.ID, signer)
+		assert.Error(t, err)
+		assert.Empty(t, tx.PayloadSignatures)
+	})
+
+	t.Run("Envelope signed by authorizer", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignEnvelope(authorizerA, key.ID, signer)
+		require.NoError(t, err)
+
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, authorizerA, tx.EnvelopeSignatures[0].Address)
+	})
+
+	t.Run("Envelope signed by proposer", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignEnvelope(proposer, key.ID, signer)
+		assert.Error(t, err)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+
+	t.Run("Unknown account", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addresses.New(), key.ID, signer)
+		assert.Error(t, err)
+
+		err = tx.SignEnvelope(addresses.New(), key.ID, signer)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_SignContext(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+	tx.PayloadSignatures = nil
+	tx.EnvelopeSignatures = nil
+
+	_, signer := test.AccountKeyGenerator().NewWithSigner()
+
+	t.Run("Context passed to signer", func(t *testing.T) {
+		s := &contextSigner{Signer: signer}
+
+		ctx := context.WithValue(context.Background(), contextKey("request"), "abc")
+
+		err := tx.SignPayloadContext(ctx, tx.ProposalKey.Address, tx.ProposalKey.KeyID, s)
+		require.NoError(t, err)
+
+		err = tx.SignEnvelopeContext(ctx, tx.Payer, 0, s)
+		require.NoError(t, err)
+
+		assert.Equal(t, []interface{}{"abc", "abc"}, s.values)
+		assert.Len(t, tx.PayloadSignatures, 1)
+		assert.Len(t, tx.EnvelopeSignatures, 1)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		tx := test.TransactionGenerator().New()
+		tx.EnvelopeSignatures = nil
+
+		err := tx.SignEnvelopeContext(ctx, tx.Payer, 0, signer)
+		assert.Equal(t, context.Canceled, err)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+}
+
+func TestTransaction_SigningRequests(t *testing.T) {
+	addresses := test.AddressGenerator()
+	accountKeys := test.AccountKeyGenerator()
+
+	addressA := addresses.New()
+	addressB := addresses.New()
+	addressC := addresses.New()
+
+	keyA, signerA := accountKeys.NewWithSigner()
+	keyA.ID = 2
+	keyB, signerB := accountKeys.NewWithSigner()
+	keyC, signerC := accountKeys.NewWithSigner()
+
+	signers := map[flow.Address]crypto.Signer{
+		addressA: signerA,
+		addressB: signerB,
+		addressC: signerC,
+	}
+
+	accounts := map[flow.Address][]*flow.AccountKey{
+		addressA: {keyA},
+		addressB: {keyB},
+		addressC: {keyC},
+	}
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.ScriptHelloWorld).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(addressA, keyA.ID, keyA.SequenceNumber).
+			AddAuthorizer(addressA).
+			AddAuthorizer(addressC).
+			SetPayer(addressB)
+	}
+
+	// signedMessage returns the message passed to the signer when signing in-process
+	signedMessage := func(sign func(crypto.Signer) error) []byte {
+		var message []byte
+
+		err := sign(crypto.CallbackSigner(func(m []byte) ([]byte, error) {
+			message = m
+			return []byte{}, nil
+		}))
+		require.NoError(t, err)
+
+		return message
+	}
+
+	t.Run("Payload then envelope", func(t *testing.T) {
+		tx := newTransaction()
+
+		requests, err := tx.SigningRequests()
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+
+		assert.Equal(t, addressA, requests[0].Address)
+		assert.Equal(t, keyA.ID, requests[0].KeyID)
+		assert.Equal(t, addressC, requests[1].Address)
+		assert.Equal(t, 0, requests[1].KeyID)
+
+		expected := signedMessage(func(s crypto.Signer) error {
+			return newTransaction().SignPayload(addressA, keyA.ID, s)
+		})
+
+		for _, request := range requests {
+			assert.False(t, request.Envelope)
+			assert.Equal(t, expected, request.Message)
+
+			sig, err := signers[request.Address].Sign(request.Message)
+			require.NoError(t, err)
+
+			request.KeyID = accounts[request.Address][0].ID
+			tx.AddSignature(request, sig)
+		}
+
+		requests, err = tx.SigningRequests()
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+
+		envelope := requests[0]
+		assert.True(t, envelope.Envelope)
+		assert.Equal(t, addressB, envelope.Address)
+
+		signed := newTransaction()
+		signed.PayloadSignatures = tx.PayloadSignatures
+
+		expected = signedMessage(func(s crypto.Signer) error {
+			return signed.SignEnvelope(addressB, keyB.ID, s)
+		})
+		assert.Equal(t, expected, envelope.Message)
+
+		sig, err := signerB.Sign(envelope.Message)
+		require.NoError(t, err)
+
+		envelope.KeyID = keyB.ID
+		tx.AddSignature(envelope, sig)
+
+		requests, err = tx.SigningRequests()
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+
+		weights, err := tx.AuthorizationWeights(accounts)
+		require.NoError(t, err)
+		assert.Len(t, weights, 3)
+	})
+
+	t.Run("Proposer is payer", func(t *testing.T) {
+		tx := newTransaction().SetPayer(addressA)
+
+		requests, err := tx.SigningRequests()
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, addressC, requests[0].Address)
+
+		sig, err := signerC.Sign(requests[0].Message)
+		require.NoError(t, err)
+		tx.AddSignature(requests[0], sig)
+
+		requests, err = tx.SigningRequests()
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+
+		assert.True(t, requests[0].Envelope)
+		assert.Equal(t, addressA, requests[0].Address)
+		assert.Equal(t, keyA.ID, requests[0].KeyID)
+	})
+
+	t.Run("Missing payer", func(t *testing.T) {
+		tx := newTransaction().SetPayer(flow.ZeroAddress)
+
+		_, err := tx.SigningRequests()
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_AuthorizationWeights(t *testing.T) {
+	addresses := test.AddressGenerator()
+	accountKeys := test.AccountKeyGenerator()
+
+	addressA := addresses.New()
+	addressB := addresses.New()
+
+	keyA1, signerA1 := accountKeys.NewWithSigner()
+	keyA1.SetWeight(500)
+
+	keyA2, signerA2 := accountKeys.NewWithSigner()
+	keyA2.SetWeight(500)
+
+	keyB1, signerB1 := accountKeys.NewWithSigner()
+	keyB1.SetWeight(flow.AccountKeyWeightThreshold)
+
+	accounts := map[flow.Address][]*flow.AccountKey{
+		addressA: {keyA1, keyA2},
+		addressB: {keyB1},
+	}
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.ScriptHelloWorld).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(addressA, keyA1.ID, keyA1.SequenceNumber).
+			AddAuthorizer(addressA).
+			SetPayer(addressB)
+	}
+
+	t.Run("Fully signed", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		err = tx.SignPayload(addressA, keyA2.ID, signerA2)
+		require.NoError(t, err)
+
+		err = tx.SignEnvelope(addressB, keyB1.ID, signerB1)
+		require.NoError(t, err)
+
+		weights, err := tx.AuthorizationWeights(accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address]int{
+			addressA: 1000,
+			addressB: 1000,
+		}, weights)
+	})
+
+	t.Run("Payload and envelope weights", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		err = tx.SignEnvelope(addressB, keyB1.ID, signerB1)
+		require.NoError(t, err)
+
+		// a key that is not the payer's can still sign the envelope
+		sig, err := signerA2.Sign(tx.EnvelopeMessage())
+		require.NoError(t, err)
+		tx.AddEnvelopeSignature(addressA, keyA2.ID, sig)
+
+		payload, envelope, err := tx.SignatureWeights(accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address]int{addressA: 500}, payload)
+		assert.Equal(t, map[flow.Address]int{addressA: 500, addressB: 1000}, envelope)
+	})
+
+	t.Run("Partially signed", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		weights, err := tx.AuthorizationWeights(accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address]int{
+			addressA: 500,
+		}, weights)
+	})
+
+	t.Run("Key counted once", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		err = tx.SignEnvelope(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		weights, err := tx.AuthorizationWeights(accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, 500, weights[addressA])
+	})
+
+	t.Run("Missing account", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		_, err = tx.AuthorizationWeights(map[flow.Address][]*flow.AccountKey{
+			addressB: {keyB1},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA1)
+		require.NoError(t, err)
+
+		_, err = tx.AuthorizationWeights(map[flow.Address][]*flow.AccountKey{
+			addressA: {keyA2},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		tx := newTransaction()
+
+		err := tx.SignPayload(addressA, keyA1.ID, signerA2)
+		require.NoError(t, err)
+
+		_, err = tx.AuthorizationWeights(accounts)
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		decoded, err := flow.DecodeTransaction(tx.Encode())
+		require.NoError(t, err)
+
+		assert.Equal(t, tx, decoded)
+		assert.Equal(t, tx.ID(), decoded.ID())
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+		tx.AddPayloadSignature(flow.HexToAddress("ff"), 0, []byte{42})
+
+		_, err := flow.DecodeTransaction(tx.Encode())
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := flow.DecodeTransaction([]byte{1, 2, 3})
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_RLPMessages(t *testing.T) {
+	blockID, err := hex.DecodeString("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b")
+	require.NoError(t, err)
+
+	address := flow.HexToAddress("01")
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction { execute { log("Hello, World!") } }`)).
+		SetReferenceBlockID(flow.BytesToID(blockID)).
+		SetGasLimit(42).
+		SetProposalKey(address, 4, 10).
+		SetPayer(address).
+		AddAuthorizer(address)
+
+	tx.AddPayloadSignature(address, 4, []byte{0x0a, 0x0b})
+
+	payload := "f895b07472616e73616374696f6e207b2065786563757465207b206c6f67282248656c6c6f2c20576f726c64212229207d207d" +
+		"a0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b2a" +
+		"940000000000000000000000000000000000000001040a" +
+		"940000000000000000000000000000000000000001" +
+		"d5940000000000000000000000000000000000000001"
+
+	envelope := "f89e" + payload + "c6c58004820a0b"
+
+	assert.Equal(t, payload, hex.EncodeToString(tx.PayloadMessage()))
+	assert.Equal(t, envelope, hex.EncodeToString(tx.EnvelopeMessage()))
+
+	tx.AddEnvelopeSignature(address, 4, []byte{0x0c, 0x0d})
+
+	encoded := "f8a5" + payload + "c6c58004820a0b" + "c6c58004820c0d"
+
+	assert.Equal(t, encoded, hex.EncodeToString(tx.Encode()))
+	assert.Equal(t, "37605d11404ccb3ac24e3d8251684c84e019299b67428a6d48a15a65e491f3f5", tx.ID().Hex())
+	assert.Equal(t, flow.HashToID(crypto.NewSHA3_256().ComputeHash(tx.Encode())), tx.ID())
+
+	t.Run("Decode", func(t *testing.T) {
+		b, err := hex.DecodeString(encoded)
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeTransaction(b)
+		require.NoError(t, err)
+
+		assert.Equal(t, tx, decoded)
+		assert.Equal(t, tx.PayloadMessage(), decoded.PayloadMessage())
+		assert.Equal(t, tx.EnvelopeMessage(), decoded.EnvelopeMessage())
+		assert.Equal(t, tx.ID(), decoded.ID())
+	})
+
+	t.Run("Signed message", func(t *testing.T) {
+		key, signer := test.AccountKeyGenerator().NewWithSigner()
+
+		tx := test.TransactionGenerator().New()
+		tx.PayloadSignatures = nil
+		tx.EnvelopeSignatures = nil
+
+		err := tx.SignPayload(tx.ProposalKey.Address, key.ID, signer)
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeTransaction(tx.Encode())
+		require.NoError(t, err)
+
+		hasher, err := key.Hasher()
+		require.NoError(t, err)
+
+		valid, err := key.PublicKey.Verify(decoded.PayloadSignatures[0].Signature, decoded.PayloadMessage(), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
+
+func TestTransaction_AddPayloadSignatureFrom(t *testing.T) {
+	addresses := test.AddressGenerator()
+	accountKeys := test.AccountKeyGenerator()
+
+	addressA := addresses.New()
+	addressB := addresses.New()
+	addressC := addresses.New()
+
+	keyA, signerA := accountKeys.NewWithSigner()
+	keyA.SetWeight(flow.AccountKeyWeightThreshold)
+
+	keyB, signerB := accountKeys.NewWithSigner()
+	keyB.SetWeight(flow.AccountKeyWeightThreshold)
+
+	keyC, signerC := accountKeys.NewWithSigner()
+	keyC.SetWeight(flow.AccountKeyWeightThreshold)
+
+	accounts := map[flow.Address][]*flow.AccountKey{
+		addressA: {keyA},
+		addressB: {keyB},
+		addressC: {keyC},
+	}
+
+	t.Run("Co-signers", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript(test.ScriptHelloWorld).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(addressA, keyA.ID, keyA.SequenceNumber).
+			AddAuthorizer(addressA).
+			AddAuthorizer(addressB).
+			AddAuthorizer(addressC).
+			SetPayer(addressA)
+
+		// the proposer signs first, then each co-signer receives the encoded transaction
+		// and adds their signature in reverse order of declaration
+		err := tx.SignPayload(addressA, keyA.ID, signerA)
+		require.NoError(t, err)
+
+		cosignedC := flow.NewTransaction()
+		err = cosignedC.AddPayloadSignatureFrom(tx.Encode(), addressC, keyC.ID, signerC)
+		require.NoError(t, err)
+
+		cosignedB := flow.NewTransaction()
+		err = cosignedB.AddPayloadSignatureFrom(cosignedC.Encode(), addressB, keyB.ID, signerB)
+		require.NoError(t, err)
+
+		require.Len(t, cosignedB.PayloadSignatures, 3)
+
+		// signatures are in canonical order
+		assert.Equal(t, addressA, cosignedB.PayloadSignatures[0].Address)
+		assert.Equal(t, addressB, cosignedB.PayloadSignatures[1].Address)
+		assert.Equal(t, addressC, cosignedB.PayloadSignatures[2].Address)
+
+		// the payload is unchanged
+		assert.Equal(t, tx.PayloadMessage(), cosignedB.PayloadMessage())
+
+		err = cosignedB.SignEnvelope(addressA, keyA.ID, signerA)
+		require.NoError(t, err)
+
+		weights, err := cosignedB.AuthorizationWeights(accounts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[flow.Address]int{
+			addressA: flow.AccountKeyWeightThreshold,
+			addressB: flow.AccountKeyWeightThreshold,
+			addressC: flow.AccountKeyWeightThreshold,
+		}, weights)
+	})
+
+	t.Run("Envelope signed", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript(test.ScriptHelloWorld).
+			SetProposalKey(addressA, keyA.ID, keyA.SequenceNumber).
+			AddAuthorizer(addressB).
+			SetPayer(addressA)
+
+		err := tx.SignEnvelope(addressA, keyA.ID, signerA)
+		require.NoError(t, err)
+
+		cosigned := flow.NewTransaction()
+		err = cosigned.AddPayloadSignatureFrom(tx.Encode(), addressB, keyB.ID, signerB)
+		assert.Error(t, err)
+
+		// the transaction is left unchanged
+		assert.Equal(t, flow.NewTransaction(), cosigned)
+	})
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		assert.NoError(t, tx.Validate())
+	})
+
+	t.Run("Missing payer and reference block", func(t *testing.T) {
+		tx := test.TransactionGenerator().NewUnsigned().
+			SetReferenceBlockID(flow.ZeroID).
+			SetPayer(flow.ZeroAddress)
+
+		err := tx.Validate()
+		require.Error(t, err)
+
+		errs, ok := err.(flow.TransactionValidationErrors)
+		require.True(t, ok)
+
+		assert.Len(t, errs, 2)
+		assert.Contains(t, err.Error(), "reference block ID is not set")
+		assert.Contains(t, err.Error(), "payer is not set")
+	})
+
+	t.Run("Empty transaction", func(t *testing.T) {
+		err := flow.NewTransaction().Validate()
+		require.Error(t, err)
+
+		errs, ok := err.(flow.TransactionValidationErrors)
+		require.True(t, ok)
+
+		// script, reference block, proposal key and payer
+		assert.Len(t, errs, 4)
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+		tx.AddPayloadSignature(flow.HexToAddress("ff"), 0, []byte{42})
+
+		err := tx.Validate()
+		assert.Error(t, err)
+	})
+}